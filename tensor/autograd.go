@@ -0,0 +1,516 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Variable wraps a Tensor as a node in a computation graph. Operations on
+// Variables record how each result was produced, so that Backward can fill in
+// Grad for every Variable that requires a gradient.
+type Variable[T Numeric, S Index] struct {
+	Value		*Tensor[T, S]
+	Grad		*Tensor[T, S]
+	RequiresGrad	bool
+	parents		[]*Variable[T, S]
+	backward	func(grad *Tensor[T, S]) error
+}
+
+// InitVariable creates a leaf of the computation graph from a copy of value.
+// Model parameters are created with requiresGrad set; inputs and targets
+// usually are not.
+func InitVariable[T Numeric, S Index](value *Tensor[T, S], requiresGrad bool) (*Variable[T, S], error) {
+	if value == nil || len(value.Shape) == 0 {
+		return &Variable[T, S]{}, errors.New("Variables require a tensor with at least one dimension")
+	}
+
+	values, err := value.elements()
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	copied, err := InitTensor[T, S](value.Shape)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+	copy(copied.Data, values)
+
+	return &Variable[T, S]{Value: copied, RequiresGrad: requiresGrad}, nil
+}
+
+// newResult creates the output of an operation. It only keeps the backward
+// closure when one of the parents needs a gradient.
+func newResult[T Numeric, S Index](
+	value *Tensor[T, S],
+	backward func(grad *Tensor[T, S]) error,
+	parents ...*Variable[T, S]) *Variable[T, S] {
+
+	result := &Variable[T, S]{Value: value}
+
+	for _, parent := range parents {
+		if parent.RequiresGrad {
+			result.RequiresGrad = true
+		}
+	}
+
+	if result.RequiresGrad {
+		result.parents = parents
+		result.backward = backward
+	}
+
+	return result
+}
+
+// accumulate adds grad into the variable's gradient, allocating it on first use.
+func (v *Variable[T, S]) accumulate(grad *Tensor[T, S]) error {
+	if !v.RequiresGrad {
+		return nil
+	}
+
+	if len(grad.Data) != len(v.Value.Data) {
+		return fmt.Errorf("Gradient of shape %v does not match value of shape %v", grad.Shape, v.Value.Shape)
+	}
+
+	if v.Grad == nil {
+		zeros, err := InitTensor[T, S](v.Value.Shape)
+		if err != nil {
+			return err
+		}
+		v.Grad = zeros
+	}
+
+	contiguous, err := grad.Contiguous()
+	if err != nil {
+		return err
+	}
+
+	for n, val := range contiguous.Data {
+		v.Grad.Data[n] += val
+	}
+
+	return nil
+}
+
+// ZeroGrad clears the accumulated gradient, typically after an update step.
+func (v *Variable[T, S]) ZeroGrad() {
+	v.Grad = nil
+}
+
+// Backward computes the gradient of this single-element Variable, usually a
+// loss, with respect to every Variable in its graph that requires one.
+// Gradients accumulate across calls until ZeroGrad is called.
+func (v *Variable[T, S]) Backward() error {
+	if len(v.Value.Data) != 1 {
+		return fmt.Errorf("Backward requires a single-element output, got shape %v", v.Value.Shape)
+	}
+
+	if !v.RequiresGrad {
+		return errors.New("Backward called on a Variable that does not require a gradient")
+	}
+
+	// order the graph so every node runs after all of the nodes that use it
+	order := make([]*Variable[T, S], 0)
+	visited := make(map[*Variable[T, S]]bool)
+
+	var visit func(node *Variable[T, S])
+	visit = func(node *Variable[T, S]) {
+		if visited[node] {
+			return
+		}
+		visited[node] = true
+
+		for _, parent := range node.parents {
+			visit(parent)
+		}
+		order = append(order, node)
+	}
+	visit(v)
+
+	// intermediate gradients belong to this pass only
+	for _, node := range order {
+		if node.backward != nil {
+			node.Grad = nil
+		}
+	}
+
+	seed, err := InitTensor[T, S](v.Value.Shape)
+	if err != nil {
+		return err
+	}
+	seed.Data[0] = T(1.0)
+
+	err = v.accumulate(seed)
+	if err != nil {
+		return err
+	}
+
+	for n := len(order) - 1; n >= 0; n-- {
+		node := order[n]
+		if node.backward == nil || node.Grad == nil {
+			continue
+		}
+
+		err = node.backward(node.Grad)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *Variable[T, S]) Dot(other *Variable[T, S]) (*Variable[T, S], error) {
+	if len(v.Value.Shape) != 2 || len(other.Value.Shape) != 2 {
+		return &Variable[T, S]{}, errors.New("Variable Dot requires 2D operands")
+	}
+
+	value, err := v.Value.Dot(other.Value)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	backward := func(grad *Tensor[T, S]) error {
+		if v.RequiresGrad {
+			transposed, err := other.Value.Transpose()
+			if err != nil {
+				return err
+			}
+
+			gradLeft, err := grad.Dot(transposed)
+			if err != nil {
+				return err
+			}
+
+			err = v.accumulate(gradLeft)
+			if err != nil {
+				return err
+			}
+		}
+
+		if other.RequiresGrad {
+			transposed, err := v.Value.Transpose()
+			if err != nil {
+				return err
+			}
+
+			gradRight, err := transposed.Dot(grad)
+			if err != nil {
+				return err
+			}
+
+			return other.accumulate(gradRight)
+		}
+
+		return nil
+	}
+
+	return newResult(value, backward, v, other), nil
+}
+
+// broadcastShape checks that other either matches v exactly or is a single
+// row [1, C] that is repeated over every row of an [N, C] v, such as a bias.
+func broadcastShape[T Numeric, S Index](v, other *Tensor[T, S]) (bool, error) {
+	if len(v.Data) == len(other.Data) && len(v.Shape) == len(other.Shape) {
+		for n := range v.Shape {
+			if v.Shape[n] != other.Shape[n] {
+				return false, fmt.Errorf("Shapes do not match: %v != %v", v.Shape, other.Shape)
+			}
+		}
+		return false, nil
+	}
+
+	if len(v.Shape) == 2 && len(other.Shape) == 2 && other.Shape[0] == 1 && other.Shape[1] == v.Shape[1] {
+		return true, nil
+	}
+
+	return false, fmt.Errorf("Shapes do not match: %v != %v", v.Shape, other.Shape)
+}
+
+// reduceBroadcast sums a gradient over its rows when the operand was broadcast.
+func reduceBroadcast[T Numeric, S Index](grad *Tensor[T, S], broadcast bool) (*Tensor[T, S], error) {
+	if !broadcast {
+		return grad, nil
+	}
+
+	numCols := grad.Shape[1]
+	result, err := InitTensor[T, S]([]S{1, numCols})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, val := range grad.Data {
+		result.Data[S(n) % numCols] += val
+	}
+
+	return result, nil
+}
+
+// Add returns v + other. other may also be a [1, C] row, such as a bias,
+// which is added to every row of an [N, C] v.
+func (v *Variable[T, S]) Add(other *Variable[T, S]) (*Variable[T, S], error) {
+	return v.combine(other, false)
+}
+
+// Subtract returns v - other, broadcasting a [1, C] other like Add.
+func (v *Variable[T, S]) Subtract(other *Variable[T, S]) (*Variable[T, S], error) {
+	return v.combine(other, true)
+}
+
+func (v *Variable[T, S]) combine(other *Variable[T, S], subtract bool) (*Variable[T, S], error) {
+	broadcast, err := broadcastShape(v.Value, other.Value)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	value, err := InitTensor[T, S](v.Value.Shape)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	numOther := len(other.Value.Data)
+	for n := range value.Data {
+		if subtract {
+			value.Data[n] = v.Value.Data[n] - other.Value.Data[n % numOther]
+		} else {
+			value.Data[n] = v.Value.Data[n] + other.Value.Data[n % numOther]
+		}
+	}
+
+	backward := func(grad *Tensor[T, S]) error {
+		err := v.accumulate(grad)
+		if err != nil {
+			return err
+		}
+
+		if !other.RequiresGrad {
+			return nil
+		}
+
+		signed := grad
+		if subtract {
+			signed, err = InitTensor[T, S](grad.Shape)
+			if err != nil {
+				return err
+			}
+
+			for n, val := range grad.Data {
+				signed.Data[n] = -val
+			}
+		}
+
+		reduced, err := reduceBroadcast(signed, broadcast)
+		if err != nil {
+			return err
+		}
+
+		return other.accumulate(reduced)
+	}
+
+	return newResult(value, backward, v, other), nil
+}
+
+// Hadamard returns the elementwise product, broadcasting a [1, C] other like Add.
+func (v *Variable[T, S]) Hadamard(other *Variable[T, S]) (*Variable[T, S], error) {
+	broadcast, err := broadcastShape(v.Value, other.Value)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	value, err := InitTensor[T, S](v.Value.Shape)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	numOther := len(other.Value.Data)
+	for n := range value.Data {
+		value.Data[n] = v.Value.Data[n] * other.Value.Data[n % numOther]
+	}
+
+	backward := func(grad *Tensor[T, S]) error {
+		if v.RequiresGrad {
+			gradLeft, err := InitTensor[T, S](v.Value.Shape)
+			if err != nil {
+				return err
+			}
+
+			for n := range gradLeft.Data {
+				gradLeft.Data[n] = grad.Data[n] * other.Value.Data[n % numOther]
+			}
+
+			err = v.accumulate(gradLeft)
+			if err != nil {
+				return err
+			}
+		}
+
+		if other.RequiresGrad {
+			gradRight, err := InitTensor[T, S](v.Value.Shape)
+			if err != nil {
+				return err
+			}
+
+			for n := range gradRight.Data {
+				gradRight.Data[n] = grad.Data[n] * v.Value.Data[n]
+			}
+
+			reduced, err := reduceBroadcast(gradRight, broadcast)
+			if err != nil {
+				return err
+			}
+
+			return other.accumulate(reduced)
+		}
+
+		return nil
+	}
+
+	return newResult(value, backward, v, other), nil
+}
+
+func (v *Variable[T, S]) MulScalar(scalar T) (*Variable[T, S], error) {
+	value, err := v.Value.MulScalar(scalar)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	backward := func(grad *Tensor[T, S]) error {
+		scaled, err := grad.MulScalar(scalar)
+		if err != nil {
+			return err
+		}
+
+		return v.accumulate(scaled)
+	}
+
+	return newResult(value, backward, v), nil
+}
+
+func (v *Variable[T, S]) AddScalar(scalar T) (*Variable[T, S], error) {
+	value, err := v.Value.AddScalar(scalar)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	backward := func(grad *Tensor[T, S]) error {
+		return v.accumulate(grad)
+	}
+
+	return newResult(value, backward, v), nil
+}
+
+// elementwise applies fn to every element, and during Backward multiplies
+// the incoming gradient by derivative(input, output).
+func (v *Variable[T, S]) elementwise(
+	fn func(x float64) float64,
+	derivative func(x, y float64) float64) (*Variable[T, S], error) {
+
+	value, err := InitTensor[T, S](v.Value.Shape)
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	for n, val := range v.Value.Data {
+		value.Data[n] = T(fn(float64(val)))
+	}
+
+	backward := func(grad *Tensor[T, S]) error {
+		local, err := InitTensor[T, S](v.Value.Shape)
+		if err != nil {
+			return err
+		}
+
+		for n := range local.Data {
+			slope := derivative(float64(v.Value.Data[n]), float64(value.Data[n]))
+			local.Data[n] = T(float64(grad.Data[n]) * slope)
+		}
+
+		return v.accumulate(local)
+	}
+
+	return newResult(value, backward, v), nil
+}
+
+func (v *Variable[T, S]) Sigmoid() (*Variable[T, S], error) {
+	return v.elementwise(stableSigmoid, func(x, y float64) float64 {
+		return y * (1.0 - y)
+	})
+}
+
+func (v *Variable[T, S]) Tanh() (*Variable[T, S], error) {
+	return v.elementwise(math.Tanh, func(x, y float64) float64 {
+		return 1.0 - y * y
+	})
+}
+
+func (v *Variable[T, S]) ReLU() (*Variable[T, S], error) {
+	return v.elementwise(func(x float64) float64 {
+		return math.Max(x, 0)
+	}, func(x, y float64) float64 {
+		if x > 0 {
+			return 1.0
+		}
+		return 0.0
+	})
+}
+
+func (v *Variable[T, S]) Exp() (*Variable[T, S], error) {
+	return v.elementwise(math.Exp, func(x, y float64) float64 {
+		return y
+	})
+}
+
+// Log clips its input to the epsilon the loss functions use, and passes no
+// gradient through clipped elements.
+func (v *Variable[T, S]) Log() (*Variable[T, S], error) {
+	return v.elementwise(func(x float64) float64 {
+		return math.Log(math.Max(x, logEpsilon))
+	}, func(x, y float64) float64 {
+		if x < logEpsilon {
+			return 0.0
+		}
+		return 1.0 / x
+	})
+}
+
+// Sum reduces every element to a [1, 1] Variable.
+func (v *Variable[T, S]) Sum() (*Variable[T, S], error) {
+	return v.reduce(T(1.0))
+}
+
+// Mean reduces every element to their [1, 1] average.
+func (v *Variable[T, S]) Mean() (*Variable[T, S], error) {
+	if len(v.Value.Data) == 0 {
+		return &Variable[T, S]{}, errors.New("Mean of an empty Variable")
+	}
+
+	return v.reduce(T(1.0) / T(len(v.Value.Data)))
+}
+
+func (v *Variable[T, S]) reduce(scale T) (*Variable[T, S], error) {
+	value, err := InitTensor[T, S]([]S{1, 1})
+	if err != nil {
+		return &Variable[T, S]{}, err
+	}
+
+	total := T(0)
+	for _, val := range v.Value.Data {
+		total += val
+	}
+	value.Data[0] = total * scale
+
+	backward := func(grad *Tensor[T, S]) error {
+		spread, err := InitTensor[T, S](v.Value.Shape)
+		if err != nil {
+			return err
+		}
+
+		for n := range spread.Data {
+			spread.Data[n] = grad.Data[0] * scale
+		}
+
+		return v.accumulate(spread)
+	}
+
+	return newResult(value, backward, v), nil
+}
@@ -0,0 +1,233 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func logisticFixture() (*Tensor[float64, uint64], *Tensor[float64, uint64], *Tensor[float64, uint64]) {
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{0.5, -1.0, 1.5, 0.3, -0.2, 0.8, 1.0, -1.2}
+
+	targets, _ := InitTensor64(4, 1)
+	targets.Data = []float64{1.0, 0.0, 1.0, 0.0}
+
+	weights, _ := InitTensor64(2, 1)
+	weights.Data = []float64{0.3, -0.4}
+
+	return features, targets, weights
+}
+
+func TestAutogradLogisticGradient(t *testing.T) {
+	features, targets, weights := logisticFixture()
+
+	x, _ := InitVariable(features, false)
+	y, _ := InitVariable(targets, false)
+	w, _ := InitVariable(weights, true)
+
+	biasValue, _ := InitTensor64(1, 1)
+	biasValue.Data[0] = 0.1
+	b, _ := InitVariable(biasValue, true)
+
+	// binary cross-entropy: -mean(y * log(p) + (1 - y) * log(1 - p))
+	z, _ := x.Dot(w)
+	z, _ = z.Add(b)
+	p, _ := z.Sigmoid()
+	logP, _ := p.Log()
+	oneMinusP, _ := p.MulScalar(-1.0)
+	oneMinusP, _ = oneMinusP.AddScalar(1.0)
+	logOneMinusP, _ := oneMinusP.Log()
+	oneMinusY, _ := y.MulScalar(-1.0)
+	oneMinusY, _ = oneMinusY.AddScalar(1.0)
+
+	positive, _ := y.Hadamard(logP)
+	negative, _ := oneMinusY.Hadamard(logOneMinusP)
+	total, _ := positive.Add(negative)
+	mean, _ := total.Mean()
+	loss, _ := mean.MulScalar(-1.0)
+
+	err := loss.Backward()
+	if err != nil {
+		t.Fatalf("Backward failed: %v\n", err)
+	}
+
+	expectedLoss, _ := CalculateCost(p.Value, targets)
+	if math.Abs(loss.Value.Data[0] - expectedLoss) > 1e-12 {
+		t.Errorf("Unexpected loss: got %v, expected %v", loss.Value.Data[0], expectedLoss)
+	}
+
+	// the hand-coded gradient used by LogisticRegressionModel: X^T (p - y) / N
+	errorTerm, _ := p.Value.Subtract(targets)
+	transposed, _ := features.Transpose()
+	expected, _ := transposed.Dot(errorTerm)
+
+	for n := range expected.Data {
+		if math.Abs(w.Grad.Data[n] - expected.Data[n] / 4.0) > 1e-12 {
+			t.Errorf("Weight gradient %v: got %v, expected %v", n, w.Grad.Data[n], expected.Data[n] / 4.0)
+		}
+	}
+
+	errorSum, _ := errorTerm.Sum()
+	if math.Abs(b.Grad.Data[0] - errorSum / 4.0) > 1e-12 {
+		t.Errorf("Bias gradient: got %v, expected %v", b.Grad.Data[0], errorSum / 4.0)
+	}
+
+	if x.Grad != nil || y.Grad != nil {
+		t.Errorf("Inputs that do not require gradients should not receive one")
+	}
+}
+
+func TestAutogradLinearRegressionGradient(t *testing.T) {
+	features, targets, weights := logisticFixture()
+
+	x, _ := InitVariable(features, false)
+	y, _ := InitVariable(targets, false)
+	w, _ := InitVariable(weights, true)
+
+	// 0.5 * sum((Xw - y)^2) has the gradient X^T (Xw - y) used by LinearRegressionModel
+	predictions, _ := x.Dot(w)
+	residuals, _ := predictions.Subtract(y)
+	squared, _ := residuals.Hadamard(residuals)
+	sum, _ := squared.Sum()
+	loss, _ := sum.MulScalar(0.5)
+
+	err := loss.Backward()
+	if err != nil {
+		t.Fatalf("Backward failed: %v\n", err)
+	}
+
+	transposed, _ := features.Transpose()
+	expected, _ := transposed.Dot(residuals.Value)
+
+	for n := range expected.Data {
+		if math.Abs(w.Grad.Data[n] - expected.Data[n]) > 1e-12 {
+			t.Errorf("Weight gradient %v: got %v, expected %v", n, w.Grad.Data[n], expected.Data[n])
+		}
+	}
+}
+
+func TestAutogradNumericGradient(t *testing.T) {
+	features, _, _ := logisticFixture()
+
+	hiddenWeights, _ := InitTensor64(2, 3)
+	hiddenWeights.Data = []float64{0.2, -0.5, 0.7, 0.4, 0.1, -0.3}
+
+	outputWeights, _ := InitTensor64(3, 1)
+	outputWeights.Data = []float64{0.6, -0.2, 0.9}
+
+	bias, _ := InitTensor64(1, 3)
+	bias.Data = []float64{0.1, -0.1, 0.05}
+
+	forward := func(w1, b1, w2 *Variable[float64, uint64]) *Variable[float64, uint64] {
+		x, _ := InitVariable(features, false)
+		hidden, _ := x.Dot(w1)
+		hidden, _ = hidden.Add(b1)
+		hidden, _ = hidden.Tanh()
+		output, _ := hidden.Dot(w2)
+		output, _ = output.Exp()
+		loss, _ := output.Mean()
+		return loss
+	}
+
+	w1, _ := InitVariable(hiddenWeights, true)
+	b1, _ := InitVariable(bias, true)
+	w2, _ := InitVariable(outputWeights, true)
+
+	loss := forward(w1, b1, w2)
+	err := loss.Backward()
+	if err != nil {
+		t.Fatalf("Backward failed: %v\n", err)
+	}
+
+	const h = 1e-6
+	for _, parameter := range []*Variable[float64, uint64]{w1, b1, w2} {
+		for n := range parameter.Value.Data {
+			original := parameter.Value.Data[n]
+
+			parameter.Value.Data[n] = original + h
+			plus := forward(w1, b1, w2).Value.Data[0]
+
+			parameter.Value.Data[n] = original - h
+			minus := forward(w1, b1, w2).Value.Data[0]
+
+			parameter.Value.Data[n] = original
+
+			numeric := (plus - minus) / (2 * h)
+			if math.Abs(numeric - parameter.Grad.Data[n]) > 1e-7 {
+				t.Errorf("Gradient mismatch at %v: numeric %v, autograd %v", n, numeric, parameter.Grad.Data[n])
+			}
+		}
+	}
+}
+
+func TestAutogradAccumulation(t *testing.T) {
+	value, _ := InitTensor64(1, 2)
+	value.Data = []float64{2.0, -3.0}
+
+	v, _ := InitVariable(value, true)
+
+	// v is used twice, so its gradient is the sum of both paths
+	square, _ := v.Hadamard(v)
+	relu, _ := v.ReLU()
+	total, _ := square.Add(relu)
+	loss, _ := total.Sum()
+
+	err := loss.Backward()
+	if err != nil {
+		t.Fatalf("Backward failed: %v\n", err)
+	}
+
+	expected := []float64{5.0, -6.0}
+	for n := range expected {
+		if v.Grad.Data[n] != expected[n] {
+			t.Errorf("Unexpected gradient: got %v, expected %v", v.Grad.Data, expected)
+		}
+	}
+
+	err = loss.Backward()
+	if err != nil {
+		t.Fatalf("Second Backward failed: %v\n", err)
+	}
+
+	if v.Grad.Data[0] != 10.0 {
+		t.Errorf("Gradients should accumulate until ZeroGrad, got %v", v.Grad.Data)
+	}
+
+	v.ZeroGrad()
+	if v.Grad != nil {
+		t.Errorf("ZeroGrad should clear the gradient")
+	}
+
+	err = total.Backward()
+	if err == nil {
+		t.Errorf("Expected an error calling Backward on a non-scalar Variable")
+	}
+}
+
+func TestInitVariableCopiesView(t *testing.T) {
+	features, _, _ := logisticFixture()
+
+	// a GetSlice row view counts as contiguous but shares its parent's tail
+	row, _ := features.GetSlice(0, 1)
+	x, err := InitVariable(row, false)
+	if err != nil {
+		t.Fatalf("InitVariable failed: %v\n", err)
+	}
+
+	expected := []float64{1.5, 0.3}
+	if len(x.Value.Data) != len(expected) {
+		t.Fatalf("Expected %v elements, got %v", len(expected), x.Value.Data)
+	}
+
+	for n := range expected {
+		if x.Value.Data[n] != expected[n] {
+			t.Errorf("Unexpected Variable value: %v", x.Value.Data)
+			break
+		}
+	}
+
+	x.Value.Data[0] = 42.0
+	if features.Data[2] != 1.5 {
+		t.Errorf("Expected the Variable not to share storage with its tensor")
+	}
+}
@@ -0,0 +1,282 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// The comparison functions return masks: tensors of the input's shape holding
+// 1 where the condition is true and 0 where it is false. Any nonzero value
+// counts as true when a mask is consumed. Comparisons involving NaN are
+// false, except NotEqual, which is true.
+
+type Comparison int
+
+const (
+	GreaterComparison Comparison = iota
+	GreaterEqualComparison
+	LessComparison
+	LessEqualComparison
+	EqualComparison
+	NotEqualComparison
+)
+
+func (c Comparison) holds(a, b float64) (bool, error) {
+	switch c {
+	case GreaterComparison:
+		return a > b, nil
+	case GreaterEqualComparison:
+		return a >= b, nil
+	case LessComparison:
+		return a < b, nil
+	case LessEqualComparison:
+		return a <= b, nil
+	case EqualComparison:
+		return a == b, nil
+	case NotEqualComparison:
+		return a != b, nil
+	}
+
+	return false, fmt.Errorf("Unknown comparison %v", c)
+}
+
+// maskValue converts a condition to the 1 or 0 stored in a mask.
+func maskValue[T Numeric](condition bool) T {
+	if condition {
+		return T(1.0)
+	}
+	return T(0.0)
+}
+
+// Compare applies comparison between a and b at every position. a and b must
+// have the same shape.
+func Compare[T Numeric, S Index](a, b *Tensor[T, S], comparison Comparison) (*Tensor[T, S], error) {
+	return unary(a, func(dst, a *Tensor[T, S]) error {
+		srcA, srcB, out, err := binaryIntoSlices(dst, a, b)
+		if err != nil {
+			return err
+		}
+
+		for n, val := range srcA {
+			holds, err := comparison.holds(float64(val), float64(srcB[n]))
+			if err != nil {
+				return err
+			}
+			out[n] = maskValue[T](holds)
+		}
+
+		return nil
+	})
+}
+
+// CompareScalar applies comparison between every element and scalar, so
+// CompareScalar(probabilities, GreaterEqualComparison, 0.5) thresholds them.
+func CompareScalar[T Numeric, S Index](input *Tensor[T, S], comparison Comparison, scalar T) (*Tensor[T, S], error) {
+	return unary(input, func(dst, input *Tensor[T, S]) error {
+		src, out, err := intoSlices(dst, input)
+		if err != nil {
+			return err
+		}
+
+		for n, val := range src {
+			holds, err := comparison.holds(float64(val), float64(scalar))
+			if err != nil {
+				return err
+			}
+			out[n] = maskValue[T](holds)
+		}
+
+		return nil
+	})
+}
+
+func Greater[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return Compare(a, b, GreaterComparison)
+}
+
+func GreaterEqual[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return Compare(a, b, GreaterEqualComparison)
+}
+
+func Less[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return Compare(a, b, LessComparison)
+}
+
+func LessEqual[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return Compare(a, b, LessEqualComparison)
+}
+
+func Equal[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return Compare(a, b, EqualComparison)
+}
+
+func NotEqual[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return Compare(a, b, NotEqualComparison)
+}
+
+// IsNaN masks the NaN elements, showing where Valid found a problem.
+func IsNaN[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, func(dst, input *Tensor[T, S]) error {
+		src, out, err := intoSlices(dst, input)
+		if err != nil {
+			return err
+		}
+
+		for n, val := range src {
+			out[n] = maskValue[T](math.IsNaN(float64(val)))
+		}
+
+		return nil
+	})
+}
+
+// IsInf masks the elements that are positive or negative infinity.
+func IsInf[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, func(dst, input *Tensor[T, S]) error {
+		src, out, err := intoSlices(dst, input)
+		if err != nil {
+			return err
+		}
+
+		for n, val := range src {
+			out[n] = maskValue[T](math.IsInf(float64(val), 0))
+		}
+
+		return nil
+	})
+}
+
+// Where takes a where cond is nonzero and b elsewhere. All three tensors
+// must have the same shape.
+func Where[T Numeric, S Index](cond, a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	if !slices.Equal(cond.Shape, a.Shape) {
+		return &Tensor[T, S]{}, fmt.Errorf("Condition shape %v does not match tensor shape %v", cond.Shape, a.Shape)
+	}
+
+	condition, err := cond.elements()
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return unary(a, func(dst, a *Tensor[T, S]) error {
+		srcA, srcB, out, err := binaryIntoSlices(dst, a, b)
+		if err != nil {
+			return err
+		}
+
+		for n, val := range condition {
+			if val != 0 {
+				out[n] = srcA[n]
+			} else {
+				out[n] = srcB[n]
+			}
+		}
+
+		return nil
+	})
+}
+
+// MaskedRows returns the rows of a 2D input whose entry in mask is nonzero,
+// in their original order. mask holds one entry per row, with shape [N] or
+// [N, 1], such as a mask from IsNaN reduced with ReduceSum along axis 1.
+func MaskedRows[T Numeric, S Index](input, mask *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(input.Shape) != 2 {
+		return &Tensor[T, S]{}, errors.New("Mask row selection requires a 2D tensor")
+	}
+
+	values, err := mask.elements()
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	if S(len(values)) != input.Shape[0] {
+		return &Tensor[T, S]{}, fmt.Errorf("Mask of shape %v does not have one entry per row of %v", mask.Shape, input.Shape)
+	}
+
+	rows := make([]int, 0, len(values))
+	for n, val := range values {
+		if val != 0 {
+			rows = append(rows, n)
+		}
+	}
+
+	return selectRows(input, rows)
+}
+
+// Any reports whether at least one element is nonzero.
+func (t *Tensor[T, S]) Any() bool {
+	values, err := t.elements()
+	if err != nil {
+		return false
+	}
+
+	for _, val := range values {
+		if val != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All reports whether every element is nonzero. An empty tensor is
+// vacuously true.
+func (t *Tensor[T, S]) All() bool {
+	values, err := t.elements()
+	if err != nil {
+		return false
+	}
+
+	for _, val := range values {
+		if val == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CountNonZero counts the nonzero elements. NaN counts as nonzero.
+func (t *Tensor[T, S]) CountNonZero() int {
+	values, err := t.elements()
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, val := range values {
+		if val != 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// NonZeroIndices returns the coordinates of every nonzero element in row-major
+// order, so IsNaN followed by NonZeroIndices lists where the NaNs are.
+func (t *Tensor[T, S]) NonZeroIndices() ([][]S, error) {
+	values, err := t.elements()
+	if err != nil {
+		return nil, err
+	}
+
+	indices := [][]S{}
+	for n, val := range values {
+		if val == 0 {
+			continue
+		}
+
+		coord := make([]S, len(t.Shape))
+		remainder := S(n)
+		for axis := len(t.Shape) - 1; axis >= 0; axis-- {
+			coord[axis] = remainder % t.Shape[axis]
+			remainder /= t.Shape[axis]
+		}
+		indices = append(indices, coord)
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,172 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestComparisons(t *testing.T) {
+	a, _ := InitTensor64(2, 2)
+	a.Data = []float64{1.0, 2.0, 3.0, math.NaN()}
+	b, _ := InitTensor64(2, 2)
+	b.Data = []float64{2.0, 2.0, 1.0, 0.0}
+
+	expected := map[string]struct {
+		op	func(a, b *Tensor[float64, uint64]) (*Tensor[float64, uint64], error)
+		mask	[]float64
+	} {
+		"Greater":	{Greater[float64, uint64], []float64{0, 0, 1, 0}},
+		"GreaterEqual":	{GreaterEqual[float64, uint64], []float64{0, 1, 1, 0}},
+		"Less":		{Less[float64, uint64], []float64{1, 0, 0, 0}},
+		"LessEqual":	{LessEqual[float64, uint64], []float64{1, 1, 0, 0}},
+		"Equal":	{Equal[float64, uint64], []float64{0, 1, 0, 0}},
+		"NotEqual":	{NotEqual[float64, uint64], []float64{1, 0, 1, 1}},
+	}
+
+	for name, test := range expected {
+		mask, err := test.op(a, b)
+		if err != nil {
+			t.Fatalf("%v failed: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(mask.Data, test.mask) || !reflect.DeepEqual(mask.Shape, a.Shape) {
+			t.Errorf("%v: got %v, expected %v", name, mask.Data, test.mask)
+		}
+	}
+
+	thresholded, _ := CompareScalar(b, GreaterComparison, 1.5)
+	if !reflect.DeepEqual(thresholded.Data, []float64{1, 1, 0, 0}) {
+		t.Errorf("Unexpected CompareScalar result: %v", thresholded.Data)
+	}
+
+	_, err := CompareScalar(b, Comparison(42), 1.5)
+	if err == nil {
+		t.Errorf("Expected an error for an unknown comparison")
+	}
+
+	wrongShape, _ := InitTensor64(3, 1)
+	_, err = Greater(a, wrongShape)
+	if err == nil {
+		t.Errorf("Expected an error for mismatched shapes")
+	}
+
+	flat, _ := InitTensor64(4)
+	_, err = Equal(a, flat)
+	if err == nil {
+		t.Errorf("Expected an error for a [4] tensor against a [2 2] one")
+	}
+}
+
+func TestWhere(t *testing.T) {
+	cond, _ := InitTensor64(2, 2)
+	cond.Data = []float64{1.0, 0.0, 0.0, 2.0}
+	a, _ := InitTensor64(2, 2)
+	a.Data = []float64{1.0, 2.0, 3.0, 4.0}
+	b, _ := InitTensor64(2, 2)
+	b.Data = []float64{-1.0, -2.0, -3.0, -4.0}
+
+	result, err := Where(cond, a, b)
+	if err != nil || !reflect.DeepEqual(result.Data, []float64{1.0, -2.0, -3.0, 4.0}) {
+		t.Errorf("Unexpected Where result: %v (%v)", result.Data, err)
+	}
+
+	// replace NaNs with zeros
+	a.Data[1] = math.NaN()
+	nans, _ := IsNaN(a)
+	zeros, _ := InitTensor64(2, 2)
+	cleaned, _ := Where(nans, zeros, a)
+	if !reflect.DeepEqual(cleaned.Data, []float64{1.0, 0.0, 3.0, 4.0}) {
+		t.Errorf("Unexpected NaN replacement: %v", cleaned.Data)
+	}
+
+	shortCond, _ := InitTensor64(3)
+	_, err = Where(shortCond, a, b)
+	if err == nil {
+		t.Errorf("Expected an error for a condition of the wrong shape")
+	}
+
+	// a transposed layout with the same element count is still a mismatch
+	wide, _ := InitTensor64(2, 3)
+	tallCond, _ := wide.Transpose()
+	_, err = Where(tallCond, wide, wide)
+	if err == nil {
+		t.Errorf("Expected an error for a [3 2] condition against [2 3] tensors")
+	}
+
+	_, err = Where(wide, wide, tallCond)
+	if err == nil {
+		t.Errorf("Expected an error for a [3 2] b against a [2 3] a")
+	}
+}
+
+func TestMaskedRows(t *testing.T) {
+	input, _ := InitTensor64(3, 2)
+	input.Data = []float64{1, 2, 3, 4, 5, 6}
+
+	mask, _ := InitTensor64(3, 1)
+	mask.Data = []float64{1, 0, 1}
+
+	selected, err := MaskedRows(input, mask)
+	if err != nil {
+		t.Fatalf("MaskedRows failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(selected.Shape, []uint64{2, 2}) || !reflect.DeepEqual(selected.Data, []float64{1, 2, 5, 6}) {
+		t.Errorf("Unexpected selected rows: %v %v", selected.Shape, selected.Data)
+	}
+
+	// rows of a transposed view
+	transposed, _ := input.Transpose()
+	columnMask, _ := InitTensor64(2)
+	columnMask.Data = []float64{0, 1}
+	selected, _ = MaskedRows(transposed, columnMask)
+	if !reflect.DeepEqual(selected.Data, []float64{2, 4, 6}) {
+		t.Errorf("Unexpected rows of a transposed view: %v", selected.Data)
+	}
+
+	_, err = MaskedRows(input, columnMask)
+	if err == nil {
+		t.Errorf("Expected an error for a mask without one entry per row")
+	}
+}
+
+func TestMaskReductions(t *testing.T) {
+	values, _ := InitTensor64(2, 3)
+	values.Data = []float64{1.0, math.NaN(), 0.0, math.Inf(-1), 5.0, 6.0}
+
+	if values.Valid() {
+		t.Fatalf("Expected Valid to report the NaN and Inf")
+	}
+
+	nans, _ := IsNaN(values)
+	infs, _ := IsInf(values)
+	if !reflect.DeepEqual(nans.Data, []float64{0, 1, 0, 0, 0, 0}) || !reflect.DeepEqual(infs.Data, []float64{0, 0, 0, 1, 0, 0}) {
+		t.Errorf("Unexpected masks: %v %v", nans.Data, infs.Data)
+	}
+
+	locations, err := nans.NonZeroIndices()
+	if err != nil || !reflect.DeepEqual(locations, [][]uint64{{0, 1}}) {
+		t.Errorf("Unexpected NaN locations: %v (%v)", locations, err)
+	}
+
+	if !nans.Any() || nans.All() || nans.CountNonZero() != 1 {
+		t.Errorf("Unexpected reductions of the NaN mask")
+	}
+
+	// NaN counts as nonzero, only the explicit zero does not
+	if values.CountNonZero() != 5 || values.All() {
+		t.Errorf("Unexpected CountNonZero %v", values.CountNonZero())
+	}
+
+	zeros, _ := InitTensor64(2, 2)
+	if zeros.Any() || zeros.CountNonZero() != 0 {
+		t.Errorf("Expected a zero tensor to have no nonzero elements")
+	}
+
+	// a row view only covers its own elements
+	row, _ := values.GetBatchSlice(1, 1)
+	if !row.All() || row.CountNonZero() != 3 {
+		t.Errorf("Unexpected reductions of a row view")
+	}
+}
@@ -0,0 +1,281 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// datasetTensors allocates the [N, F] features and [N, 1] targets returned by
+// every generator.
+func datasetTensors[T Numeric, S Index](numSamples, numFeatures S) (*Tensor[T, S], *Tensor[T, S], error) {
+	if numSamples == 0 || numFeatures == 0 {
+		return nil, nil, errors.New("Datasets require at least one sample and one feature")
+	}
+
+	features, err := InitTensor[T, S]([]S{numSamples, numFeatures})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets, err := InitTensor[T, S]([]S{numSamples, 1})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return features, targets, nil
+}
+
+// shuffleRows applies the same random permutation to the rows of features and
+// targets.
+func shuffleRows[T Numeric, S Index](features, targets *Tensor[T, S], r *rand.Rand) {
+	numFeatures := int(features.Shape[1])
+
+	r.Shuffle(int(features.Shape[0]), func(i, j int) {
+		for m := 0; m < numFeatures; m++ {
+			features.Data[i * numFeatures + m], features.Data[j * numFeatures + m] = features.Data[j * numFeatures + m], features.Data[i * numFeatures + m]
+		}
+		targets.Data[i], targets.Data[j] = targets.Data[j], targets.Data[i]
+	})
+}
+
+// MakeRegression draws standard normal features and computes targets as a
+// linear combination of the first numInformative of them, plus bias and
+// Gaussian noise with standard deviation noise. The remaining features carry
+// no signal. It returns the [N, F] features, [N, 1] targets and the [F, 1]
+// true coefficients, which are zero for the uninformative features.
+func MakeRegression[T Numeric, S Index](
+	numSamples S,
+	numFeatures S,
+	numInformative S,
+	bias float64,
+	noise float64,
+	seed int64) (*Tensor[T, S], *Tensor[T, S], *Tensor[T, S], error) {
+
+	if numInformative > numFeatures {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("numInformative (%v) cannot exceed numFeatures (%v)", numInformative, numFeatures)
+	}
+
+	if noise < 0 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("noise must be non-negative, got %v", noise)
+	}
+
+	features, targets, err := datasetTensors[T, S](numSamples, numFeatures)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	coefficients, err := InitTensor[T, S]([]S{numFeatures, 1})
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	r := newSeededRand(seed)
+
+	weights := make([]float64, numFeatures)
+	for m := S(0); m < numInformative; m++ {
+		weights[m] = r.Float64() * 20.0 - 10.0
+		coefficients.Data[m] = T(weights[m])
+	}
+
+	for n := S(0); n < numSamples; n++ {
+		target := bias + r.NormFloat64() * noise
+		for m := S(0); m < numFeatures; m++ {
+			val := r.NormFloat64()
+			features.Data[n * numFeatures + m] = T(val)
+			target += weights[m] * val
+		}
+
+		targets.Data[n] = T(target)
+	}
+
+	return features, targets, coefficients, nil
+}
+
+// MakeClassification places one cluster of standard normal points per class
+// on distinct vertices of a hypercube with side 2 * classSeparation, and
+// returns the [N, F] features with [N, 1] class indices 0 .. numClasses - 1.
+// With two classes the targets are the 0/1 labels used by the binary models.
+// Classes are balanced and the rows are shuffled.
+func MakeClassification[T Numeric, S Index](
+	numSamples S,
+	numFeatures S,
+	numClasses S,
+	classSeparation float64,
+	seed int64) (*Tensor[T, S], *Tensor[T, S], error) {
+
+	if numClasses < 2 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("MakeClassification requires at least 2 classes, got %v", numClasses)
+	}
+
+	// hypercube vertices are only distinct for up to 2^F classes
+	if numFeatures < 63 && uint64(numClasses) > uint64(1) << uint64(numFeatures) {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("%v features cannot separate %v classes", numFeatures, numClasses)
+	}
+
+	features, targets, err := datasetTensors[T, S](numSamples, numFeatures)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	r := newSeededRand(seed)
+
+	// pick a distinct vertex for every class by its bit pattern
+	used := make(map[uint64]bool)
+	centers := make([][]float64, numClasses)
+	for c := range centers {
+		var vertex uint64
+		for {
+			vertex = r.Uint64()
+			if numFeatures < 64 {
+				vertex &= uint64(1) << uint64(numFeatures) - 1
+			}
+			if !used[vertex] {
+				break
+			}
+		}
+		used[vertex] = true
+
+		centers[c] = make([]float64, numFeatures)
+		for m := range centers[c] {
+			centers[c][m] = -classSeparation
+			if m < 64 && vertex & (uint64(1) << uint64(m)) != 0 {
+				centers[c][m] = classSeparation
+			}
+		}
+	}
+
+	for n := S(0); n < numSamples; n++ {
+		class := n % numClasses
+		for m := S(0); m < numFeatures; m++ {
+			features.Data[n * numFeatures + m] = T(centers[class][m] + r.NormFloat64())
+		}
+		targets.Data[n] = T(class)
+	}
+
+	shuffleRows(features, targets, r)
+	return features, targets, nil
+}
+
+// MakeBlobs draws isotropic Gaussian clusters with standard deviation
+// clusterStd around numCenters centers spread uniformly over [-10, 10] in
+// every dimension. Targets are the [N, 1] cluster indices, and the [C, F]
+// centers are returned for comparison with KMeans.
+func MakeBlobs[T Numeric, S Index](
+	numSamples S,
+	numFeatures S,
+	numCenters S,
+	clusterStd float64,
+	seed int64) (*Tensor[T, S], *Tensor[T, S], *Tensor[T, S], error) {
+
+	if numCenters == 0 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, errors.New("MakeBlobs requires at least one center")
+	}
+
+	if clusterStd < 0 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("clusterStd must be non-negative, got %v", clusterStd)
+	}
+
+	features, targets, err := datasetTensors[T, S](numSamples, numFeatures)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	centers, err := InitTensor[T, S]([]S{numCenters, numFeatures})
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	r := newSeededRand(seed)
+
+	centerValues := make([]float64, numCenters * numFeatures)
+	for n := range centerValues {
+		centerValues[n] = r.Float64() * 20.0 - 10.0
+		centers.Data[n] = T(centerValues[n])
+	}
+
+	for n := S(0); n < numSamples; n++ {
+		center := n % numCenters
+		for m := S(0); m < numFeatures; m++ {
+			features.Data[n * numFeatures + m] = T(centerValues[center * numFeatures + m] + r.NormFloat64() * clusterStd)
+		}
+		targets.Data[n] = T(center)
+	}
+
+	shuffleRows(features, targets, r)
+	return features, targets, centers, nil
+}
+
+// MakeMoons returns two interleaving half circles in two dimensions, labelled
+// 0 and 1, with Gaussian noise of standard deviation noise. No straight line
+// separates them.
+func MakeMoons[T Numeric, S Index](numSamples S, noise float64, seed int64) (*Tensor[T, S], *Tensor[T, S], error) {
+	if noise < 0 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("noise must be non-negative, got %v", noise)
+	}
+
+	features, targets, err := datasetTensors[T, S](numSamples, 2)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	r := newSeededRand(seed)
+
+	numOuter := (numSamples + 1) / 2
+	for n := S(0); n < numSamples; n++ {
+		var x, y float64
+		if n < numOuter {
+			angle := math.Pi * float64(n) / float64(max(numOuter - 1, 1))
+			x, y = math.Cos(angle), math.Sin(angle)
+		} else {
+			numInner := numSamples - numOuter
+			angle := math.Pi * float64(n - numOuter) / float64(max(numInner - 1, 1))
+			x, y = 1.0 - math.Cos(angle), 0.5 - math.Sin(angle)
+			targets.Data[n] = T(1.0)
+		}
+
+		features.Data[n * 2] = T(x + r.NormFloat64() * noise)
+		features.Data[n * 2 + 1] = T(y + r.NormFloat64() * noise)
+	}
+
+	shuffleRows(features, targets, r)
+	return features, targets, nil
+}
+
+// MakeCircles returns a large circle (label 0) around a smaller one (label 1)
+// whose radius is factor times the outer one, with Gaussian noise of standard
+// deviation noise.
+func MakeCircles[T Numeric, S Index](numSamples S, factor float64, noise float64, seed int64) (*Tensor[T, S], *Tensor[T, S], error) {
+	if factor <= 0 || factor >= 1 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("factor must be between 0 and 1, got %v", factor)
+	}
+
+	if noise < 0 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("noise must be non-negative, got %v", noise)
+	}
+
+	features, targets, err := datasetTensors[T, S](numSamples, 2)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	r := newSeededRand(seed)
+
+	numOuter := (numSamples + 1) / 2
+	for n := S(0); n < numSamples; n++ {
+		radius := 1.0
+		angle := 2.0 * math.Pi * float64(n) / float64(numOuter)
+		if n >= numOuter {
+			radius = factor
+			angle = 2.0 * math.Pi * float64(n - numOuter) / float64(max(numSamples - numOuter, 1))
+			targets.Data[n] = T(1.0)
+		}
+
+		features.Data[n * 2] = T(radius * math.Cos(angle) + r.NormFloat64() * noise)
+		features.Data[n * 2 + 1] = T(radius * math.Sin(angle) + r.NormFloat64() * noise)
+	}
+
+	shuffleRows(features, targets, r)
+	return features, targets, nil
+}
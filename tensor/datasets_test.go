@@ -0,0 +1,178 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMakeRegression(t *testing.T) {
+	features, targets, coefficients, err := MakeRegression[float64, uint64](100, 5, 3, 4.0, 0.0, 1)
+	if err != nil {
+		t.Fatalf("MakeRegression failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(features.Shape, []uint64{100, 5}) || !reflect.DeepEqual(targets.Shape, []uint64{100, 1}) || !reflect.DeepEqual(coefficients.Shape, []uint64{5, 1}) {
+		t.Fatalf("Unexpected shapes: %v %v %v", features.Shape, targets.Shape, coefficients.Shape)
+	}
+
+	if coefficients.Data[3] != 0.0 || coefficients.Data[4] != 0.0 || coefficients.Data[0] == 0.0 {
+		t.Errorf("Expected only the first 3 features to be informative: %v", coefficients.Data)
+	}
+
+	// without noise the targets are exactly linear in the features
+	linear, _ := features.Dot(coefficients)
+	for n := range targets.Data {
+		if math.Abs(targets.Data[n] - (linear.Data[n] + 4.0)) > 1e-9 {
+			t.Fatalf("Target %v is not linear in the features: %v != %v", n, targets.Data[n], linear.Data[n] + 4.0)
+		}
+	}
+
+	again, _, _, _ := MakeRegression[float64, uint64](100, 5, 3, 4.0, 0.0, 1)
+	if !reflect.DeepEqual(again.Data, features.Data) {
+		t.Errorf("Expected the same seed to give the same dataset")
+	}
+
+	_, _, _, err = MakeRegression[float64, uint64](100, 2, 3, 0.0, 0.0, 1)
+	if err == nil {
+		t.Errorf("Expected an error for more informative features than features")
+	}
+
+	// coordinate descent recovers the coefficients through the noise
+	features, targets, coefficients, _ = MakeRegression[float64, uint64](500, 4, 2, -3.0, 0.1, 2)
+	X, _ := features.AugmentBias()
+
+	lrm, _ := InitLinearRegressionModel[float64, uint64](5, 0.0, 0.0, 0.0, 1000)
+	err = lrm.FitCoordinateDescent(X, targets)
+	if err != nil {
+		t.Fatalf("FitCoordinateDescent failed: %v", err)
+	}
+
+	if math.Abs(lrm.Weights.Data[0] + 3.0) > 0.05 {
+		t.Errorf("Expected a bias near -3, got %v", lrm.Weights.Data[0])
+	}
+
+	for m, coefficient := range coefficients.Data {
+		if math.Abs(lrm.Weights.Data[m + 1] - coefficient) > 0.05 {
+			t.Errorf("Feature %v: expected weight %v, got %v", m, coefficient, lrm.Weights.Data[m + 1])
+		}
+	}
+}
+
+func classLabels(targets *Tensor[float64, uint64]) []string {
+	labels := make([]string, len(targets.Data))
+	for n, val := range targets.Data {
+		labels[n] = fmt.Sprintf("%v", val)
+	}
+
+	return labels
+}
+
+func TestMakeClassification(t *testing.T) {
+	features, targets, err := MakeClassification[float64, uint64](300, 4, 3, 3.0, 3)
+	if err != nil {
+		t.Fatalf("MakeClassification failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(features.Shape, []uint64{300, 4}) || !reflect.DeepEqual(targets.Shape, []uint64{300, 1}) {
+		t.Fatalf("Unexpected shapes: %v %v", features.Shape, targets.Shape)
+	}
+
+	counts := make(map[float64]int)
+	for _, val := range targets.Data {
+		counts[val]++
+	}
+	if counts[0.0] != 100 || counts[1.0] != 100 || counts[2.0] != 100 {
+		t.Errorf("Expected balanced classes, got %v", counts)
+	}
+
+	// well separated classes are easy for a nearest-neighbor model
+	knn, _ := InitKNN[float64, uint64](5, AutoAlgorithm)
+	knn.Fit(features, classLabels(targets))
+
+	testFeatures, testTargets, _ := MakeClassification[float64, uint64](100, 4, 3, 3.0, 3)
+	predictions, _ := knn.PredictBatch(testFeatures)
+
+	correct := 0
+	for n, label := range classLabels(testTargets) {
+		if predictions[n] == label {
+			correct++
+		}
+	}
+	if correct < 95 {
+		t.Errorf("Expected separable classes, only %v of 100 predicted correctly", correct)
+	}
+
+	_, _, err = MakeClassification[float64, uint64](10, 1, 3, 1.0, 3)
+	if err == nil {
+		t.Errorf("Expected an error for more classes than hypercube vertices")
+	}
+}
+
+func TestMakeBlobs(t *testing.T) {
+	features, targets, centers, err := MakeBlobs[float64, uint64](200, 3, 4, 0.5, 4)
+	if err != nil {
+		t.Fatalf("MakeBlobs failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(centers.Shape, []uint64{4, 3}) {
+		t.Fatalf("Unexpected centers shape: %v", centers.Shape)
+	}
+
+	// every point stays within a few standard deviations of its own center
+	for n := uint64(0); n < 200; n++ {
+		center := uint64(targets.Data[n])
+		distance := euclideanDistance(features.Data[n * 3: n * 3 + 3], centers.Data[center * 3: center * 3 + 3])
+		if distance > 3.0 {
+			t.Errorf("Point %v is %v away from its center", n, distance)
+		}
+	}
+}
+
+func TestMakeMoonsAndCircles(t *testing.T) {
+	moons, moonTargets, err := MakeMoons[float64, uint64](101, 0.0, 5)
+	if err != nil {
+		t.Fatalf("MakeMoons failed: %v", err)
+	}
+
+	for n := uint64(0); n < 101; n++ {
+		x, y := moons.Data[n * 2], moons.Data[n * 2 + 1]
+		if moonTargets.Data[n] == 1.0 {
+			x, y = x - 1.0, y - 0.5
+		}
+
+		if math.Abs(math.Hypot(x, y) - 1.0) > 1e-9 {
+			t.Errorf("Moon point %v is off its half circle", n)
+		}
+	}
+
+	circles, circleTargets, err := MakeCircles[float64, uint64](100, 0.4, 0.0, 6)
+	if err != nil {
+		t.Fatalf("MakeCircles failed: %v", err)
+	}
+
+	inner := 0
+	for n := uint64(0); n < 100; n++ {
+		radius := math.Hypot(circles.Data[n * 2], circles.Data[n * 2 + 1])
+
+		expected := 1.0
+		if circleTargets.Data[n] == 1.0 {
+			expected = 0.4
+			inner++
+		}
+
+		if math.Abs(radius - expected) > 1e-9 {
+			t.Errorf("Circle point %v has radius %v, expected %v", n, radius, expected)
+		}
+	}
+
+	if inner != 50 {
+		t.Errorf("Expected 50 points on the inner circle, got %v", inner)
+	}
+
+	_, _, err = MakeCircles[float64, uint64](100, 1.5, 0.0, 6)
+	if err == nil {
+		t.Errorf("Expected an error for a factor outside (0, 1)")
+	}
+}
@@ -0,0 +1,611 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+type SplitCriterion int
+
+const (
+	GiniCriterion SplitCriterion = iota
+	EntropyCriterion
+	MSECriterion
+)
+
+// TreeNode is one node of a fitted tree. Internal nodes send samples whose
+// Feature value is <= Threshold to Left and the rest to Right. Value holds the
+// mean target of a regression node or the majority class index of a
+// classification node, whose class proportions are kept in Distribution.
+type TreeNode struct {
+	Feature		int
+	Threshold	float64
+	Left		*TreeNode
+	Right		*TreeNode
+	Value		float64
+	Distribution	[]float64
+	Impurity	float64
+	NumSamples	int
+}
+
+func (node *TreeNode) IsLeaf() bool {
+	return node.Left == nil && node.Right == nil
+}
+
+type treeBuilder[T Numeric, S Index] struct {
+	data		[]T
+	numFeatures	int
+	criterion	SplitCriterion
+	maxDepth	S
+	minSamplesLeaf	int
+	maxFeatures	int
+	rng		*rand.Rand
+	classes		[]int
+	numClasses	int
+	targets		[]float64
+	importances	[]float64
+}
+
+func (builder *treeBuilder[T, S]) value(sample, feature int) float64 {
+	return float64(builder.data[sample * builder.numFeatures + feature])
+}
+
+func classImpurity(counts []float64, total float64, criterion SplitCriterion) float64 {
+	if total == 0 {
+		return 0.0
+	}
+
+	impurity := 0.0
+	if criterion == EntropyCriterion {
+		for _, count := range counts {
+			if count > 0 {
+				p := count / total
+				impurity -= p * math.Log2(p)
+			}
+		}
+		return impurity
+	}
+
+	impurity = 1.0
+	for _, count := range counts {
+		p := count / total
+		impurity -= p * p
+	}
+	return impurity
+}
+
+func varianceImpurity(sum, sumOfSquares, total float64) float64 {
+	if total == 0 {
+		return 0.0
+	}
+
+	mean := sum / total
+	return math.Max(sumOfSquares / total - mean * mean, 0.0)
+}
+
+func (builder *treeBuilder[T, S]) leaf(samples []int) *TreeNode {
+	node := &TreeNode{NumSamples: len(samples)}
+	total := float64(len(samples))
+
+	if builder.criterion == MSECriterion {
+		sum := 0.0
+		sumOfSquares := 0.0
+		for _, sample := range samples {
+			y := builder.targets[sample]
+			sum += y
+			sumOfSquares += y * y
+		}
+
+		node.Value = sum / total
+		node.Impurity = varianceImpurity(sum, sumOfSquares, total)
+		return node
+	}
+
+	counts := make([]float64, builder.numClasses)
+	for _, sample := range samples {
+		counts[builder.classes[sample]]++
+	}
+
+	best := 0
+	node.Distribution = make([]float64, builder.numClasses)
+	for c, count := range counts {
+		node.Distribution[c] = count / total
+		if count > counts[best] {
+			best = c
+		}
+	}
+
+	node.Value = float64(best)
+	node.Impurity = classImpurity(counts, total, builder.criterion)
+	return node
+}
+
+func (builder *treeBuilder[T, S]) candidateFeatures() []int {
+	if builder.maxFeatures <= 0 || builder.maxFeatures >= builder.numFeatures {
+		features := make([]int, builder.numFeatures)
+		for n := range features {
+			features[n] = n
+		}
+		return features
+	}
+
+	return builder.rng.Perm(builder.numFeatures)[:builder.maxFeatures]
+}
+
+// splitThreshold returns a threshold t with current <= t < next, so the
+// <= test reproduces the sorted split. The midpoint of adjacent floats can
+// round up to next, and of huge values can overflow, so fall back to current.
+func splitThreshold(current, next float64) float64 {
+	mid := (current + next) / 2.0
+	if mid >= current && mid < next {
+		return mid
+	}
+
+	return current
+}
+
+func (builder *treeBuilder[T, S]) build(samples []int, depth S) *TreeNode {
+	node := builder.leaf(samples)
+
+	if builder.maxDepth > 0 && depth >= builder.maxDepth {
+		return node
+	}
+
+	numSamples := len(samples)
+	if numSamples < 2 * builder.minSamplesLeaf || node.Impurity <= 1e-12 {
+		return node
+	}
+
+	total := float64(numSamples)
+	bestFeature := -1
+	bestThreshold := 0.0
+	bestChildImpurity := math.Inf(1)
+	bestSplit := 0
+
+	sorted := make([]int, numSamples)
+	leftCounts := make([]float64, builder.numClasses)
+	rightCounts := make([]float64, builder.numClasses)
+
+	for _, feature := range builder.candidateFeatures() {
+		copy(sorted, samples)
+		sort.Slice(sorted, func(i, j int) bool {
+			return builder.value(sorted[i], feature) < builder.value(sorted[j], feature)
+		})
+
+		leftSum, leftSquares := 0.0, 0.0
+		rightSum, rightSquares := 0.0, 0.0
+
+		if builder.criterion == MSECriterion {
+			for _, sample := range sorted {
+				y := builder.targets[sample]
+				rightSum += y
+				rightSquares += y * y
+			}
+		} else {
+			for c := range leftCounts {
+				leftCounts[c] = 0
+				rightCounts[c] = 0
+			}
+			for _, sample := range sorted {
+				rightCounts[builder.classes[sample]]++
+			}
+		}
+
+		for split := 1; split < numSamples; split++ {
+			moved := sorted[split - 1]
+
+			if builder.criterion == MSECriterion {
+				y := builder.targets[moved]
+				leftSum += y
+				leftSquares += y * y
+				rightSum -= y
+				rightSquares -= y * y
+			} else {
+				leftCounts[builder.classes[moved]]++
+				rightCounts[builder.classes[moved]]--
+			}
+
+			if split < builder.minSamplesLeaf || numSamples - split < builder.minSamplesLeaf {
+				continue
+			}
+
+			current := builder.value(moved, feature)
+			next := builder.value(sorted[split], feature)
+			if current == next {
+				continue
+			}
+
+			leftTotal := float64(split)
+			rightTotal := total - leftTotal
+
+			var childImpurity float64
+			if builder.criterion == MSECriterion {
+				childImpurity = leftTotal * varianceImpurity(leftSum, leftSquares, leftTotal) +
+					rightTotal * varianceImpurity(rightSum, rightSquares, rightTotal)
+			} else {
+				childImpurity = leftTotal * classImpurity(leftCounts, leftTotal, builder.criterion) +
+					rightTotal * classImpurity(rightCounts, rightTotal, builder.criterion)
+			}
+
+			if childImpurity < bestChildImpurity - 1e-12 {
+				bestChildImpurity = childImpurity
+				bestFeature = feature
+				bestThreshold = splitThreshold(current, next)
+				bestSplit = split
+			}
+		}
+	}
+
+	// like CART, an impure node is split even when the best split does not
+	// lower the impurity, since deeper splits may (XOR is the classic case)
+	if bestFeature < 0 {
+		return node
+	}
+
+	left := make([]int, 0, bestSplit)
+	right := make([]int, 0, numSamples - bestSplit)
+	for _, sample := range samples {
+		if builder.value(sample, bestFeature) <= bestThreshold {
+			left = append(left, sample)
+		} else {
+			right = append(right, sample)
+		}
+	}
+
+	builder.importances[bestFeature] += math.Max(node.Impurity * total - bestChildImpurity, 0.0)
+
+	node.Feature = bestFeature
+	node.Threshold = bestThreshold
+	node.Left = builder.build(left, depth + 1)
+	node.Right = builder.build(right, depth + 1)
+
+	return node
+}
+
+func (node *TreeNode) find(row []float64) *TreeNode {
+	current := node
+	for !current.IsLeaf() {
+		if row[current.Feature] <= current.Threshold {
+			current = current.Left
+		} else {
+			current = current.Right
+		}
+	}
+
+	return current
+}
+
+func treeRows[T Numeric, S Index](features *Tensor[T, S], numFeatures S) ([][]float64, error) {
+	if len(features.Shape) != 2 {
+		return nil, errors.New("Decision trees require a 2D feature tensor")
+	}
+
+	if features.Shape[1] != numFeatures {
+		return nil, fmt.Errorf("Expecting %v features, got %v", numFeatures, features.Shape[1])
+	}
+
+	return matrixToFloat64(features)
+}
+
+func normalizedImportances[T Numeric](importances []float64) []T {
+	total := 0.0
+	for _, val := range importances {
+		total += val
+	}
+
+	result := make([]T, len(importances))
+	if total == 0 {
+		return result
+	}
+
+	for n, val := range importances {
+		result[n] = T(val / total)
+	}
+
+	return result
+}
+
+func newTreeBuilder[T Numeric, S Index](
+	features *Tensor[T, S],
+	criterion SplitCriterion,
+	maxDepth S,
+	minSamplesLeaf S,
+	maxFeatures S,
+	seed int64) (*treeBuilder[T, S], error) {
+
+	if len(features.Shape) != 2 {
+		return nil, errors.New("Decision trees require a 2D feature tensor")
+	}
+
+	if features.Shape[0] == 0 {
+		return nil, errors.New("Decision trees require at least one sample")
+	}
+
+	points, err := features.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &treeBuilder[T, S] {
+		data:		points.Data,
+		numFeatures:	int(features.Shape[1]),
+		criterion:	criterion,
+		maxDepth:	maxDepth,
+		minSamplesLeaf:	max(1, int(minSamplesLeaf)),
+		maxFeatures:	int(maxFeatures),
+		rng:		newSeededRand(seed),
+		importances:	make([]float64, features.Shape[1]),
+	}
+
+	return builder, nil
+}
+
+func allSamples(numSamples int) []int {
+	samples := make([]int, numSamples)
+	for n := range samples {
+		samples[n] = n
+	}
+
+	return samples
+}
+
+type DecisionTreeClassifier[T Numeric, S Index] struct {
+	Criterion		SplitCriterion
+	MaxDepth		S
+	MinSamplesLeaf		S
+	MaxFeatures		S
+	Seed			int64
+	Classes			[]string
+	Root			*TreeNode
+	FeatureImportances	[]T
+	NumFeatures		S
+}
+
+func InitDecisionTreeClassifier[T Numeric, S Index](
+	criterion SplitCriterion,
+	maxDepth S,
+	minSamplesLeaf S) (*DecisionTreeClassifier[T, S], error) {
+
+	if criterion != GiniCriterion && criterion != EntropyCriterion {
+		return &DecisionTreeClassifier[T, S]{}, errors.New("Classification trees require the Gini or entropy criterion")
+	}
+
+	model := &DecisionTreeClassifier[T, S] {
+		Criterion:	criterion,
+		MaxDepth:	maxDepth,
+		MinSamplesLeaf:	max(1, minSamplesLeaf),
+	}
+
+	return model, nil
+}
+
+func (tree *DecisionTreeClassifier[T, S]) Fit(features *Tensor[T, S], labels []string) error {
+	classes, encoded := encodeLabels(labels)
+	return tree.fitEncoded(features, classes, encoded, nil)
+}
+
+// fitEncoded trains on the given rows of features, which may repeat for
+// bootstrap samples. A nil samples slice uses every row once.
+func (tree *DecisionTreeClassifier[T, S]) fitEncoded(
+	features *Tensor[T, S],
+	classes []string,
+	encoded []int,
+	samples []int) error {
+
+	if tree.Criterion != GiniCriterion && tree.Criterion != EntropyCriterion {
+		return errors.New("Classification trees require the Gini or entropy criterion")
+	}
+
+	builder, err := newTreeBuilder(features, tree.Criterion, tree.MaxDepth, tree.MinSamplesLeaf, tree.MaxFeatures, tree.Seed)
+	if err != nil {
+		return err
+	}
+
+	if len(encoded) != int(features.Shape[0]) {
+		return fmt.Errorf("Expecting %v labels, got %v", features.Shape[0], len(encoded))
+	}
+
+	builder.classes = encoded
+	builder.numClasses = len(classes)
+
+	if samples == nil {
+		samples = allSamples(len(encoded))
+	}
+
+	tree.Classes = classes
+	tree.NumFeatures = features.Shape[1]
+	tree.Root = builder.build(samples, 0)
+	tree.FeatureImportances = normalizedImportances[T](builder.importances)
+
+	return nil
+}
+
+func (tree *DecisionTreeClassifier[T, S]) PredictProba(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if tree.Root == nil {
+		return &Tensor[T, S]{}, errors.New("DecisionTreeClassifier must be fitted (call Fit)")
+	}
+
+	rows, err := treeRows(features, tree.NumFeatures)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	numClasses := S(len(tree.Classes))
+	result, err := InitTensor[T, S]([]S{S(len(rows)), numClasses})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, row := range rows {
+		leaf := tree.Root.find(row)
+		for c, p := range leaf.Distribution {
+			result.Data[S(n) * numClasses + S(c)] = T(p)
+		}
+	}
+
+	return result, nil
+}
+
+func (tree *DecisionTreeClassifier[T, S]) Predict(features *Tensor[T, S]) ([]string, error) {
+	if tree.Root == nil {
+		return nil, errors.New("DecisionTreeClassifier must be fitted (call Fit)")
+	}
+
+	rows, err := treeRows(features, tree.NumFeatures)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make([]string, len(rows))
+	for n, row := range rows {
+		predictions[n] = tree.Classes[int(tree.Root.find(row).Value)]
+	}
+
+	return predictions, nil
+}
+
+func (tree *DecisionTreeClassifier[T, S]) Dump(featureNames []string) string {
+	return dumpTree(tree.Root, featureNames, func(node *TreeNode) string {
+		return fmt.Sprintf("class: %v", tree.Classes[int(node.Value)])
+	})
+}
+
+type DecisionTreeRegressor[T Numeric, S Index] struct {
+	MaxDepth		S
+	MinSamplesLeaf		S
+	MaxFeatures		S
+	Seed			int64
+	Root			*TreeNode
+	FeatureImportances	[]T
+	NumFeatures		S
+}
+
+func InitDecisionTreeRegressor[T Numeric, S Index](maxDepth S, minSamplesLeaf S) (*DecisionTreeRegressor[T, S], error) {
+	model := &DecisionTreeRegressor[T, S] {
+		MaxDepth:	maxDepth,
+		MinSamplesLeaf:	max(1, minSamplesLeaf),
+	}
+
+	return model, nil
+}
+
+func (tree *DecisionTreeRegressor[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	if len(features.Shape) != 2 {
+		return errors.New("Decision trees require a 2D feature tensor")
+	}
+
+	if len(targets.Data) != int(features.Shape[0]) {
+		return fmt.Errorf("Expecting %v targets, got %v", features.Shape[0], len(targets.Data))
+	}
+
+	y := make([]float64, len(targets.Data))
+	for n := range y {
+		y[n] = float64(targets.Data[n])
+	}
+
+	return tree.fitTargets(features, y, nil)
+}
+
+func (tree *DecisionTreeRegressor[T, S]) fitTargets(features *Tensor[T, S], targets []float64, samples []int) error {
+	builder, err := newTreeBuilder(features, MSECriterion, tree.MaxDepth, tree.MinSamplesLeaf, tree.MaxFeatures, tree.Seed)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) != int(features.Shape[0]) {
+		return fmt.Errorf("Expecting %v targets, got %v", features.Shape[0], len(targets))
+	}
+
+	builder.targets = targets
+
+	if samples == nil {
+		samples = allSamples(len(targets))
+	}
+
+	tree.NumFeatures = features.Shape[1]
+	tree.Root = builder.build(samples, 0)
+	tree.FeatureImportances = normalizedImportances[T](builder.importances)
+
+	return nil
+}
+
+func (tree *DecisionTreeRegressor[T, S]) predictRows(rows [][]float64) []float64 {
+	predictions := make([]float64, len(rows))
+	for n, row := range rows {
+		predictions[n] = tree.Root.find(row).Value
+	}
+
+	return predictions
+}
+
+func (tree *DecisionTreeRegressor[T, S]) Predict(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if tree.Root == nil {
+		return &Tensor[T, S]{}, errors.New("DecisionTreeRegressor must be fitted (call Fit)")
+	}
+
+	rows, err := treeRows(features, tree.NumFeatures)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	result, err := InitTensor[T, S]([]S{S(len(rows)), 1})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, val := range tree.predictRows(rows) {
+		result.Data[n] = T(val)
+	}
+
+	return result, nil
+}
+
+func (tree *DecisionTreeRegressor[T, S]) Dump(featureNames []string) string {
+	return dumpTree(tree.Root, featureNames, func(node *TreeNode) string {
+		return fmt.Sprintf("value: %.4g", node.Value)
+	})
+}
+
+// dumpTree renders a tree one line per branch, e.g.
+//
+//	|--- petal_length <= 2.45
+//	|   |--- class: setosa
+//	|--- petal_length >  2.45
+//	|   |--- class: versicolor
+func dumpTree(root *TreeNode, featureNames []string, describeLeaf func(*TreeNode) string) string {
+	if root == nil {
+		return ""
+	}
+
+	name := func(feature int) string {
+		if feature < len(featureNames) {
+			return featureNames[feature]
+		}
+		return fmt.Sprintf("feature_%v", feature)
+	}
+
+	var builder strings.Builder
+
+	var walk func(node *TreeNode, depth int)
+	walk = func(node *TreeNode, depth int) {
+		indent := strings.Repeat("|   ", depth)
+
+		if node.IsLeaf() {
+			fmt.Fprintf(&builder, "%v|--- %v\n", indent, describeLeaf(node))
+			return
+		}
+
+		fmt.Fprintf(&builder, "%v|--- %v <= %.4g\n", indent, name(node.Feature), node.Threshold)
+		walk(node.Left, depth + 1)
+		fmt.Fprintf(&builder, "%v|--- %v >  %.4g\n", indent, name(node.Feature), node.Threshold)
+		walk(node.Right, depth + 1)
+	}
+
+	walk(root, 0)
+
+	return builder.String()
+}
@@ -0,0 +1,232 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func treeDepth(node *TreeNode) int {
+	if node.IsLeaf() {
+		return 0
+	}
+
+	return 1 + max(treeDepth(node.Left), treeDepth(node.Right))
+}
+
+func TestDecisionTreeClassifierFit(t *testing.T) {
+	features, _ := InitTensor64(6, 2)
+	features.Data = []float64{1.0, 7.0, 2.0, 3.0, 3.0, 5.0, 6.0, 4.0, 7.0, 6.0, 8.0, 3.0}
+
+	labels := []string{"low", "low", "low", "high", "high", "high"}
+
+	for _, criterion := range []SplitCriterion{GiniCriterion, EntropyCriterion} {
+		tree, err := InitDecisionTreeClassifier[float64, uint64](criterion, 0, 1)
+		if err != nil {
+			t.Fatalf("InitDecisionTreeClassifier failed: %v\n", err)
+		}
+
+		err = tree.Fit(features, labels)
+		if err != nil {
+			t.Fatalf("Classifier Fit failed: %v\n", err)
+		}
+
+		predictions, err := tree.Predict(features)
+		if err != nil {
+			t.Fatalf("Classifier Predict failed: %v\n", err)
+		}
+
+		for n := range labels {
+			if predictions[n] != labels[n] {
+				t.Errorf("Unexpected predictions with criterion %v: got %v, expected %v", criterion, predictions, labels)
+				break
+			}
+		}
+
+		if tree.Root.Feature != 0 || tree.Root.Threshold != 4.5 {
+			t.Errorf("Unexpected root split: feature %v at %v", tree.Root.Feature, tree.Root.Threshold)
+		}
+
+		if tree.FeatureImportances[0] != 1.0 || tree.FeatureImportances[1] != 0.0 {
+			t.Errorf("Unexpected feature importances: %v", tree.FeatureImportances)
+		}
+	}
+}
+
+func TestDecisionTreeClassifierXOR(t *testing.T) {
+	features, _ := InitTensor64(8, 2)
+	features.Data = []float64{0, 0, 0, 0, 1, 1, 1, 1, 0, 1, 0, 1, 1, 0, 1, 0}
+	labels := []string{"no", "no", "no", "no", "yes", "yes", "yes", "yes"}
+
+	shallow, _ := InitDecisionTreeClassifier[float64, uint64](GiniCriterion, 1, 1)
+	shallow.Fit(features, labels)
+
+	if treeDepth(shallow.Root) > 1 {
+		t.Errorf("MaxDepth of 1 not respected: depth %v", treeDepth(shallow.Root))
+	}
+
+	deep, _ := InitDecisionTreeClassifier[float64, uint64](GiniCriterion, 0, 1)
+	deep.Fit(features, labels)
+
+	predictions, _ := deep.Predict(features)
+	for n := range labels {
+		if predictions[n] != labels[n] {
+			t.Errorf("Unlimited tree failed to learn XOR: got %v", predictions)
+			break
+		}
+	}
+
+	if treeDepth(deep.Root) != 2 {
+		t.Errorf("XOR should need exactly two levels, got %v", treeDepth(deep.Root))
+	}
+}
+
+func TestDecisionTreeMinSamplesLeaf(t *testing.T) {
+	features, _ := InitTensor64(6, 1)
+	features.Data = []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}
+	labels := []string{"a", "b", "b", "b", "b", "b"}
+
+	tree, _ := InitDecisionTreeClassifier[float64, uint64](GiniCriterion, 0, 2)
+	tree.Fit(features, labels)
+
+	var check func(node *TreeNode)
+	check = func(node *TreeNode) {
+		if node.IsLeaf() {
+			if node.NumSamples < 2 {
+				t.Errorf("Leaf with %v samples violates MinSamplesLeaf of 2", node.NumSamples)
+			}
+			return
+		}
+		check(node.Left)
+		check(node.Right)
+	}
+	check(tree.Root)
+
+	probabilities, err := tree.PredictProba(features)
+	if err != nil {
+		t.Fatalf("PredictProba failed: %v\n", err)
+	}
+
+	if math.Abs(probabilities.Data[0] - 0.5) > 1e-9 || math.Abs(probabilities.Data[1] - 0.5) > 1e-9 {
+		t.Errorf("Unexpected class proportions in the first leaf: %v", probabilities.Data[:2])
+	}
+}
+
+func TestDecisionTreeRegressor(t *testing.T) {
+	features, _ := InitTensor64(6, 1)
+	features.Data = []float64{1.0, 2.0, 3.0, 7.0, 8.0, 9.0}
+
+	targets, _ := InitTensor64(6, 1)
+	targets.Data = []float64{1.0, 1.0, 1.0, 10.0, 10.0, 12.0}
+
+	tree, err := InitDecisionTreeRegressor[float64, uint64](1, 1)
+	if err != nil {
+		t.Fatalf("InitDecisionTreeRegressor failed: %v\n", err)
+	}
+
+	err = tree.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("Regressor Fit failed: %v\n", err)
+	}
+
+	query, _ := InitTensor64(2, 1)
+	query.Data = []float64{0.0, 100.0}
+
+	predictions, err := tree.Predict(query)
+	if err != nil {
+		t.Fatalf("Regressor Predict failed: %v\n", err)
+	}
+
+	expected := []float64{1.0, 32.0 / 3.0}
+	for n := range expected {
+		if math.Abs(predictions.Data[n] - expected[n]) > 1e-9 {
+			t.Errorf("Unexpected regression predictions: got %v, expected %v", predictions.Data, expected)
+			break
+		}
+	}
+
+	if tree.Root.Threshold != 5.0 {
+		t.Errorf("Unexpected regression split threshold: %v", tree.Root.Threshold)
+	}
+}
+
+func TestDecisionTreeDump(t *testing.T) {
+	features, _ := InitTensor64(4, 1)
+	features.Data = []float64{1.0, 2.0, 3.0, 4.0}
+	labels := []string{"small", "small", "large", "large"}
+
+	tree, _ := InitDecisionTreeClassifier[float64, uint64](GiniCriterion, 0, 1)
+	tree.Fit(features, labels)
+
+	expected := "|--- size <= 2.5\n" +
+		"|   |--- class: small\n" +
+		"|--- size >  2.5\n" +
+		"|   |--- class: large\n"
+
+	dump := tree.Dump([]string{"size"})
+	if dump != expected {
+		t.Errorf("Unexpected tree dump:\n%v\nexpected:\n%v", dump, expected)
+	}
+
+	unnamed := tree.Dump(nil)
+	if unnamed[:15] != "|--- feature_0 " {
+		t.Errorf("Unnamed features should fall back to feature_N: %v", unnamed)
+	}
+}
+
+func TestDecisionTreeValidation(t *testing.T) {
+	_, err := InitDecisionTreeClassifier[float64, uint64](MSECriterion, 0, 1)
+	if err == nil {
+		t.Errorf("MSE criterion accepted for a classification tree")
+	}
+
+	tree, _ := InitDecisionTreeClassifier[float64, uint64](GiniCriterion, 0, 1)
+
+	_, err = tree.Predict(&Tensor[float64, uint64]{})
+	if err == nil {
+		t.Errorf("Predict before Fit did not fail")
+	}
+
+	features, _ := InitTensor64(2, 2)
+	err = tree.Fit(features, []string{"a"})
+	if err == nil {
+		t.Errorf("Mismatched label count accepted by Fit")
+	}
+
+	tree.Fit(features, []string{"a", "b"})
+	wrongWidth, _ := InitTensor64(1, 3)
+	_, err = tree.Predict(wrongWidth)
+	if err == nil {
+		t.Errorf("Feature count mismatch accepted by Predict")
+	}
+}
+
+func TestDecisionTreeAdjacentValues(t *testing.T) {
+	// the midpoint of these adjacent floats rounds up to the larger one, which
+	// would send both samples left
+	low := math.Nextafter(1.0, 2.0)
+	high := math.Nextafter(low, 2.0)
+	if (low + high) / 2.0 != high {
+		t.Fatalf("Expected the midpoint of %v and %v to round up", low, high)
+	}
+
+	features, _ := InitTensor64(2, 1)
+	features.Data = []float64{low, high}
+
+	targets, _ := InitTensor64(2, 1)
+	targets.Data = []float64{0.0, 1.0}
+
+	tree, _ := InitDecisionTreeRegressor[float64, uint64](0, 1)
+	err := tree.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("Regressor Fit failed: %v\n", err)
+	}
+
+	if tree.Root.IsLeaf() || tree.Root.Left.NumSamples != 1 || tree.Root.Right.NumSamples != 1 {
+		t.Fatalf("Expected one sample on each side of threshold %v", tree.Root.Threshold)
+	}
+
+	predictions, _ := tree.Predict(features)
+	if predictions.Data[0] != 0.0 || predictions.Data[1] != 1.0 {
+		t.Errorf("Unexpected predictions at adjacent values: %v", predictions.Data)
+	}
+}
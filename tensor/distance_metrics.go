@@ -0,0 +1,114 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type DistanceMetric int
+
+const (
+	EuclideanMetric DistanceMetric = iota
+	ManhattanMetric
+	ChebyshevMetric
+	MinkowskiMetric
+	CosineMetric
+	CustomMetric
+)
+
+// DistanceFunc measures the distance between two feature rows of equal length.
+type DistanceFunc func(a, b []float64) float64
+
+func euclideanDistance(a, b []float64) float64 {
+	sum := 0.0
+	for n := range a {
+		diff := a[n] - b[n]
+		sum += diff * diff
+	}
+
+	return math.Sqrt(sum)
+}
+
+func manhattanDistance(a, b []float64) float64 {
+	sum := 0.0
+	for n := range a {
+		sum += math.Abs(a[n] - b[n])
+	}
+
+	return sum
+}
+
+func chebyshevDistance(a, b []float64) float64 {
+	largest := 0.0
+	for n := range a {
+		largest = math.Max(largest, math.Abs(a[n] - b[n]))
+	}
+
+	return largest
+}
+
+func minkowskiDistance(p float64) DistanceFunc {
+	return func(a, b []float64) float64 {
+		sum := 0.0
+		for n := range a {
+			sum += math.Pow(math.Abs(a[n] - b[n]), p)
+		}
+
+		return math.Pow(sum, 1.0 / p)
+	}
+}
+
+// cosineDistance is 1 - cos(angle between a and b). A zero vector has no
+// direction, so its distance to anything is 1.
+func cosineDistance(a, b []float64) float64 {
+	dot := 0.0
+	normA := 0.0
+	normB := 0.0
+	for n := range a {
+		dot += a[n] * b[n]
+		normA += a[n] * a[n]
+		normB += b[n] * b[n]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 1.0
+	}
+
+	return 1.0 - dot / math.Sqrt(normA * normB)
+}
+
+// resolveDistance returns the distance function for a metric. p is only used
+// by MinkowskiMetric and custom only by CustomMetric.
+func resolveDistance(metric DistanceMetric, p float64, custom DistanceFunc) (DistanceFunc, error) {
+	switch metric {
+	case EuclideanMetric:
+		return euclideanDistance, nil
+	case ManhattanMetric:
+		return manhattanDistance, nil
+	case ChebyshevMetric:
+		return chebyshevDistance, nil
+	case MinkowskiMetric:
+		if p < 1 {
+			return nil, fmt.Errorf("Minkowski distance requires p >= 1, got %v", p)
+		}
+		return minkowskiDistance(p), nil
+	case CosineMetric:
+		return cosineDistance, nil
+	case CustomMetric:
+		if custom == nil {
+			return nil, errors.New("CustomMetric requires a distance function")
+		}
+		return custom, nil
+	}
+
+	return nil, fmt.Errorf("Unknown distance metric %v", metric)
+}
+
+// supportsSpatialIndex reports whether a metric satisfies the triangle
+// inequality and is bounded below by the gap in any single coordinate, which
+// is what KD-tree and ball tree pruning rely on. Cosine distance is not a true
+// metric and nothing is known about custom functions.
+func supportsSpatialIndex(metric DistanceMetric) bool {
+	return metric != CosineMetric && metric != CustomMetric
+}
@@ -0,0 +1,304 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type UnknownHandling int
+
+const (
+	// ErrorOnUnknown rejects labels that were not seen during Fit.
+	ErrorOnUnknown UnknownHandling = iota
+	// IgnoreUnknown encodes unseen labels as LabelEncoder.UnknownValue, or as
+	// an all-zero block in OneHotEncoder, and decodes them as "".
+	IgnoreUnknown
+)
+
+// LabelEncoder maps string labels to class indices and back. Classes are
+// sorted, so the same labels always get the same indices; with two classes
+// the encoded values are the 0/1 targets used by LogisticRegressionModel and
+// GenerateConfusionMatrix, the second class in sorted order being 1.
+type LabelEncoder[T Numeric, S Index] struct {
+	Classes		[]string
+	HandleUnknown	UnknownHandling
+	UnknownValue	T
+	indexByClass	map[string]int
+}
+
+func (encoder *LabelEncoder[T, S]) Fit(labels []string) error {
+	if len(labels) == 0 {
+		return errors.New("LabelEncoder Fit requires at least one label")
+	}
+
+	encoder.Classes, _ = encodeLabels(labels)
+	encoder.indexByClass = nil
+
+	return nil
+}
+
+// index looks up a class, rebuilding the lookup table if Classes was set
+// directly.
+func (encoder *LabelEncoder[T, S]) index(label string) (int, bool) {
+	if len(encoder.indexByClass) != len(encoder.Classes) {
+		encoder.indexByClass = make(map[string]int, len(encoder.Classes))
+		for n, class := range encoder.Classes {
+			encoder.indexByClass[class] = n
+		}
+	}
+
+	n, ok := encoder.indexByClass[label]
+	return n, ok
+}
+
+// validUnknownValue makes sure UnknownValue cannot be mistaken for a class.
+func (encoder *LabelEncoder[T, S]) validUnknownValue() error {
+	val := float64(encoder.UnknownValue)
+	if val >= 0 && val < float64(len(encoder.Classes)) && val == math.Trunc(val) {
+		return fmt.Errorf("UnknownValue %v collides with a class index", encoder.UnknownValue)
+	}
+
+	return nil
+}
+
+// Transform returns the [N, 1] class indices of labels.
+func (encoder *LabelEncoder[T, S]) Transform(labels []string) (*Tensor[T, S], error) {
+	if len(encoder.Classes) == 0 {
+		return &Tensor[T, S]{}, errors.New("LabelEncoder must be fitted (call Fit)")
+	}
+
+	if encoder.HandleUnknown == IgnoreUnknown {
+		err := encoder.validUnknownValue()
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+	}
+
+	result, err := InitTensor[T, S]([]S{S(len(labels)), 1})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, label := range labels {
+		class, ok := encoder.index(label)
+		if !ok {
+			if encoder.HandleUnknown != IgnoreUnknown {
+				return &Tensor[T, S]{}, fmt.Errorf("Unknown label %q at position %v", label, n)
+			}
+
+			result.Data[n] = encoder.UnknownValue
+			continue
+		}
+
+		result.Data[n] = T(class)
+	}
+
+	return result, nil
+}
+
+// FitTransform fits the encoder on labels and returns their class indices.
+func (encoder *LabelEncoder[T, S]) FitTransform(labels []string) (*Tensor[T, S], error) {
+	err := encoder.Fit(labels)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return encoder.Transform(labels)
+}
+
+// InverseTransform maps class indices, such as the output of Predict on a
+// classifier trained with encoded targets, back to labels.
+func (encoder *LabelEncoder[T, S]) InverseTransform(encoded *Tensor[T, S]) ([]string, error) {
+	if len(encoder.Classes) == 0 {
+		return nil, errors.New("LabelEncoder must be fitted (call Fit)")
+	}
+
+	if encoder.HandleUnknown == IgnoreUnknown {
+		err := encoder.validUnknownValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := encoded.elements()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, len(values))
+	for n, val := range values {
+		if encoder.HandleUnknown == IgnoreUnknown && val == encoder.UnknownValue {
+			continue
+		}
+
+		class := float64(val)
+		if class < 0 || class >= float64(len(encoder.Classes)) || class != math.Trunc(class) {
+			return nil, fmt.Errorf("Value %v at position %v is not a class index", val, n)
+		}
+
+		labels[n] = encoder.Classes[int(class)]
+	}
+
+	return labels, nil
+}
+
+// OneHotEncoder turns categorical columns into indicator features. Each
+// column gets one output feature per category, in sorted order, and the
+// blocks for the columns are laid side by side.
+type OneHotEncoder[T Numeric, S Index] struct {
+	Categories	[][]string
+	HandleUnknown	UnknownHandling
+}
+
+// checkColumns verifies every column has the same number of samples.
+func checkColumns(columns [][]string) (int, error) {
+	if len(columns) == 0 {
+		return 0, errors.New("OneHotEncoder requires at least one column")
+	}
+
+	numSamples := len(columns[0])
+	for m, column := range columns {
+		if len(column) != numSamples {
+			return 0, fmt.Errorf("Column %v has %v values, expecting %v", m, len(column), numSamples)
+		}
+	}
+
+	return numSamples, nil
+}
+
+// Fit learns the categories of each column. columns[m][n] is the value of
+// column m for sample n, so a single label slice is [][]string{labels}.
+func (encoder *OneHotEncoder[T, S]) Fit(columns [][]string) error {
+	numSamples, err := checkColumns(columns)
+	if err != nil {
+		return err
+	}
+
+	if numSamples == 0 {
+		return errors.New("OneHotEncoder Fit requires at least one sample")
+	}
+
+	encoder.Categories = make([][]string, len(columns))
+	for m, column := range columns {
+		encoder.Categories[m], _ = encodeLabels(column)
+	}
+
+	return nil
+}
+
+// NumOutputs is the total number of indicator features.
+func (encoder *OneHotEncoder[T, S]) NumOutputs() int {
+	total := 0
+	for _, categories := range encoder.Categories {
+		total += len(categories)
+	}
+
+	return total
+}
+
+// Transform returns the [N, NumOutputs()] indicator matrix.
+func (encoder *OneHotEncoder[T, S]) Transform(columns [][]string) (*Tensor[T, S], error) {
+	if len(encoder.Categories) == 0 {
+		return &Tensor[T, S]{}, errors.New("OneHotEncoder must be fitted (call Fit)")
+	}
+
+	numSamples, err := checkColumns(columns)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	if len(columns) != len(encoder.Categories) {
+		return &Tensor[T, S]{}, fmt.Errorf("Expecting %v columns, got %v", len(encoder.Categories), len(columns))
+	}
+
+	numOutputs := encoder.NumOutputs()
+	result, err := InitTensor[T, S]([]S{S(numSamples), S(numOutputs)})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	offset := 0
+	for m, column := range columns {
+		indexByCategory := make(map[string]int, len(encoder.Categories[m]))
+		for n, category := range encoder.Categories[m] {
+			indexByCategory[category] = n
+		}
+
+		for n, val := range column {
+			category, ok := indexByCategory[val]
+			if !ok {
+				if encoder.HandleUnknown != IgnoreUnknown {
+					return &Tensor[T, S]{}, fmt.Errorf("Unknown category %q in column %v at position %v", val, m, n)
+				}
+				continue
+			}
+
+			result.Data[n * numOutputs + offset + category] = T(1.0)
+		}
+
+		offset += len(encoder.Categories[m])
+	}
+
+	return result, nil
+}
+
+// FitTransform fits the encoder on columns and returns their indicators.
+func (encoder *OneHotEncoder[T, S]) FitTransform(columns [][]string) (*Tensor[T, S], error) {
+	err := encoder.Fit(columns)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return encoder.Transform(columns)
+}
+
+// InverseTransform recovers the categorical columns from an indicator matrix.
+// Each block decodes to its largest entry, so class probabilities such as the
+// output of SoftmaxRegressionModel.PredictProba decode to the most likely
+// class. All-zero blocks decode to "" when unknowns are ignored.
+func (encoder *OneHotEncoder[T, S]) InverseTransform(encoded *Tensor[T, S]) ([][]string, error) {
+	if len(encoder.Categories) == 0 {
+		return nil, errors.New("OneHotEncoder must be fitted (call Fit)")
+	}
+
+	numOutputs := encoder.NumOutputs()
+	if len(encoded.Shape) != 2 || encoded.Shape[1] != S(numOutputs) {
+		return nil, fmt.Errorf("Expecting a [N, %v] tensor, got shape %v", numOutputs, encoded.Shape)
+	}
+
+	rows, err := matrixToFloat64(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([][]string, len(encoder.Categories))
+	offset := 0
+	for m, categories := range encoder.Categories {
+		columns[m] = make([]string, len(rows))
+
+		for n, row := range rows {
+			block := row[offset: offset + len(categories)]
+
+			best := -1
+			for c, val := range block {
+				if val != 0 && (best < 0 || val > block[best]) {
+					best = c
+				}
+			}
+
+			if best < 0 {
+				if encoder.HandleUnknown != IgnoreUnknown {
+					return nil, fmt.Errorf("Row %v has no category set for column %v", n, m)
+				}
+				continue
+			}
+
+			columns[m][n] = categories[best]
+		}
+
+		offset += len(categories)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,143 @@
+package tensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLabelEncoder(t *testing.T) {
+	labels := []string{"spam", "ham", "spam", "ham", "ham"}
+
+	encoder := &LabelEncoder[float64, uint64]{}
+	encoded, err := encoder.FitTransform(labels)
+	if err != nil {
+		t.Fatalf("LabelEncoder FitTransform failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(encoder.Classes, []string{"ham", "spam"}) {
+		t.Errorf("Expected sorted classes, got %v", encoder.Classes)
+	}
+
+	if encoded.Shape[0] != 5 || encoded.Shape[1] != 1 {
+		t.Fatalf("Expected a [5, 1] tensor, got %v", encoded.Shape)
+	}
+
+	if !reflect.DeepEqual(encoded.Data, []float64{1.0, 0.0, 1.0, 0.0, 0.0}) {
+		t.Errorf("Unexpected encoding: %v", encoded.Data)
+	}
+
+	// the encoding feeds straight into the binary metrics
+	matrix, err := GenerateConfusionMatrix(encoded, encoded)
+	if err != nil || matrix.TruePositives != 2 || matrix.TrueNegatives != 3 {
+		t.Errorf("Unexpected confusion matrix from encoded labels: %v (%v)", matrix, err)
+	}
+
+	decoded, err := encoder.InverseTransform(encoded)
+	if err != nil || !reflect.DeepEqual(decoded, labels) {
+		t.Errorf("Expected %v, got %v (%v)", labels, decoded, err)
+	}
+
+	_, err = encoder.Transform([]string{"ham", "eggs"})
+	if err == nil {
+		t.Errorf("Expected an error for an unknown label")
+	}
+
+	encoder.HandleUnknown = IgnoreUnknown
+	_, err = encoder.Transform([]string{"eggs"})
+	if err == nil {
+		t.Errorf("Expected an error when UnknownValue collides with a class")
+	}
+
+	// with the default UnknownValue of 0, class 0 must not decode as unknown
+	_, err = encoder.InverseTransform(encoded)
+	if err == nil {
+		t.Errorf("Expected InverseTransform to reject an UnknownValue that collides with a class")
+	}
+
+	encoder.UnknownValue = -1.0
+	encoded, err = encoder.Transform([]string{"ham", "eggs"})
+	if err != nil || !reflect.DeepEqual(encoded.Data, []float64{0.0, -1.0}) {
+		t.Errorf("Unexpected encoding with unknowns: %v (%v)", encoded.Data, err)
+	}
+
+	decoded, _ = encoder.InverseTransform(encoded)
+	if !reflect.DeepEqual(decoded, []string{"ham", ""}) {
+		t.Errorf("Expected unknowns to decode to an empty label, got %v", decoded)
+	}
+
+	// class 0 round-trips under IgnoreUnknown
+	encoded, _ = encoder.Transform(labels)
+	decoded, err = encoder.InverseTransform(encoded)
+	if err != nil || !reflect.DeepEqual(decoded, labels) {
+		t.Errorf("Expected %v to round-trip under IgnoreUnknown, got %v (%v)", labels, decoded, err)
+	}
+
+	encoded.Data[0] = 5.0
+	_, err = encoder.InverseTransform(encoded)
+	if err == nil {
+		t.Errorf("Expected an error decoding an out of range index")
+	}
+}
+
+func TestOneHotEncoder(t *testing.T) {
+	columns := [][]string {
+		{"red", "green", "blue", "green"},
+		{"small", "large", "large", "small"},
+	}
+
+	encoder := &OneHotEncoder[float64, uint64]{}
+	encoded, err := encoder.FitTransform(columns)
+	if err != nil {
+		t.Fatalf("OneHotEncoder FitTransform failed: %v", err)
+	}
+
+	if encoder.NumOutputs() != 5 || encoded.Shape[0] != 4 || encoded.Shape[1] != 5 {
+		t.Fatalf("Expected a [4, 5] tensor, got %v", encoded.Shape)
+	}
+
+	// blue green red | large small
+	expected := []float64 {
+		0, 0, 1, 0, 1,
+		0, 1, 0, 1, 0,
+		1, 0, 0, 1, 0,
+		0, 1, 0, 0, 1,
+	}
+	if !reflect.DeepEqual(encoded.Data, expected) {
+		t.Errorf("Unexpected one-hot encoding: %v", encoded.Data)
+	}
+
+	decoded, err := encoder.InverseTransform(encoded)
+	if err != nil || !reflect.DeepEqual(decoded, columns) {
+		t.Errorf("Expected %v, got %v (%v)", columns, decoded, err)
+	}
+
+	unknown := [][]string{{"purple"}, {"small"}}
+	_, err = encoder.Transform(unknown)
+	if err == nil {
+		t.Errorf("Expected an error for an unknown category")
+	}
+
+	encoder.HandleUnknown = IgnoreUnknown
+	encoded, err = encoder.Transform(unknown)
+	if err != nil || !reflect.DeepEqual(encoded.Data, []float64{0, 0, 0, 0, 1}) {
+		t.Errorf("Expected an all-zero block for an unknown category, got %v (%v)", encoded.Data, err)
+	}
+
+	decoded, _ = encoder.InverseTransform(encoded)
+	if !reflect.DeepEqual(decoded, [][]string{{""}, {"small"}}) {
+		t.Errorf("Unexpected decoding with unknowns: %v", decoded)
+	}
+
+	// probabilities decode to the most likely category
+	probabilities, _ := InitTensor64(1, 5)
+	probabilities.Data = []float64{0.2, 0.7, 0.1, 0.4, 0.6}
+	decoded, _ = encoder.InverseTransform(probabilities)
+	if !reflect.DeepEqual(decoded, [][]string{{"green"}, {"small"}}) {
+		t.Errorf("Unexpected decoding of probabilities: %v", decoded)
+	}
+
+	_, err = encoder.Transform([][]string{{"red"}, {"small", "large"}})
+	if err == nil {
+		t.Errorf("Expected an error for columns of different lengths")
+	}
+}
@@ -0,0 +1,388 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+type gradientBoostingSettings[T Numeric, S Index] struct {
+	numEstimators	S
+	learningRate	T
+	maxDepth	S
+	minSamplesLeaf	S
+	subsample	T
+	rng		*rand.Rand
+}
+
+func (settings gradientBoostingSettings[T, S]) validate() error {
+	if settings.numEstimators == 0 {
+		return errors.New("Gradient boosting requires at least one estimator")
+	}
+
+	if float64(settings.learningRate) <= 0 {
+		return fmt.Errorf("Gradient boosting learning rate must be positive, got %v", settings.learningRate)
+	}
+
+	subsample := float64(settings.subsample)
+	if subsample <= 0 || subsample > 1 {
+		return fmt.Errorf("Gradient boosting subsample must be in (0, 1], got %v", settings.subsample)
+	}
+
+	return nil
+}
+
+// stageSamples draws the rows used by one boosting stage, sampling without
+// replacement when subsample is below 1 (stochastic gradient boosting).
+func (settings gradientBoostingSettings[T, S]) stageSamples(numSamples int) []int {
+	subsample := float64(settings.subsample)
+	if subsample >= 1 {
+		return allSamples(numSamples)
+	}
+
+	count := max(1, int(subsample * float64(numSamples)))
+	return settings.rng.Perm(numSamples)[:count]
+}
+
+type GradientBoostingRegressor[T Numeric, S Index] struct {
+	NumEstimators		S
+	LearningRate		T
+	MaxDepth		S
+	MinSamplesLeaf		S
+	Subsample		T
+	Seed			int64
+	InitialPrediction	T
+	Trees			[]*DecisionTreeRegressor[T, S]
+	TrainLoss		[]T
+	NumFeatures		S
+}
+
+// InitGradientBoostingRegressor fits numEstimators regression trees of depth
+// maxDepth to the residuals of the squared error, shrinking each tree's
+// contribution by learningRate.
+func InitGradientBoostingRegressor[T Numeric, S Index](
+	numEstimators S,
+	learningRate T,
+	maxDepth S) (*GradientBoostingRegressor[T, S], error) {
+
+	subsample := 1.0
+
+	model := &GradientBoostingRegressor[T, S] {
+		NumEstimators:	numEstimators,
+		LearningRate:	learningRate,
+		MaxDepth:	maxDepth,
+		MinSamplesLeaf:	1,
+		Subsample:	T(subsample),
+	}
+
+	err := model.settings().validate()
+	if err != nil {
+		return &GradientBoostingRegressor[T, S]{}, err
+	}
+
+	return model, nil
+}
+
+func (model *GradientBoostingRegressor[T, S]) settings() gradientBoostingSettings[T, S] {
+	return gradientBoostingSettings[T, S]{model.NumEstimators, model.LearningRate, model.MaxDepth, model.MinSamplesLeaf, model.Subsample, nil}
+}
+
+func (model *GradientBoostingRegressor[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	settings := model.settings()
+	err := settings.validate()
+	if err != nil {
+		return err
+	}
+	settings.rng = newSeededRand(model.Seed)
+
+	if len(features.Shape) != 2 {
+		return errors.New("Gradient boosting requires a 2D feature tensor")
+	}
+
+	rows, err := treeRows(features, features.Shape[1])
+	if err != nil {
+		return err
+	}
+
+	numSamples := len(rows)
+	if numSamples == 0 || len(targets.Data) != numSamples {
+		return fmt.Errorf("Expecting %v targets, got %v", numSamples, len(targets.Data))
+	}
+
+	y := make([]float64, numSamples)
+	mean := 0.0
+	for n := range y {
+		y[n] = float64(targets.Data[n])
+		mean += y[n]
+	}
+	mean /= float64(numSamples)
+
+	current := make([]float64, numSamples)
+	for n := range current {
+		current[n] = mean
+	}
+
+	residuals := make([]float64, numSamples)
+	learningRate := float64(model.LearningRate)
+
+	model.NumFeatures = features.Shape[1]
+	model.InitialPrediction = T(mean)
+	model.Trees = make([]*DecisionTreeRegressor[T, S], 0, model.NumEstimators)
+	model.TrainLoss = make([]T, 0, model.NumEstimators)
+
+	for stage := S(0); stage < model.NumEstimators; stage++ {
+		for n := range residuals {
+			residuals[n] = y[n] - current[n]
+		}
+
+		tree := &DecisionTreeRegressor[T, S] {
+			MaxDepth:	model.MaxDepth,
+			MinSamplesLeaf:	model.MinSamplesLeaf,
+			Seed:		settings.rng.Int63() + 1,
+		}
+
+		err = tree.fitTargets(features, residuals, settings.stageSamples(numSamples))
+		if err != nil {
+			return fmt.Errorf("Fitting stage %v failed: %v", stage, err)
+		}
+
+		loss := 0.0
+		for n, update := range tree.predictRows(rows) {
+			current[n] += learningRate * update
+			diff := y[n] - current[n]
+			loss += diff * diff
+		}
+
+		model.Trees = append(model.Trees, tree)
+		model.TrainLoss = append(model.TrainLoss, T(loss / float64(numSamples)))
+	}
+
+	return nil
+}
+
+func (model *GradientBoostingRegressor[T, S]) Predict(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(model.Trees) == 0 {
+		return &Tensor[T, S]{}, errors.New("GradientBoostingRegressor must be fitted (call Fit)")
+	}
+
+	rows, err := treeRows(features, model.NumFeatures)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	predictions := boostedScores(rows, float64(model.InitialPrediction), float64(model.LearningRate), model.Trees)
+
+	result, err := InitTensor[T, S]([]S{S(len(rows)), 1})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, val := range predictions {
+		result.Data[n] = T(val)
+	}
+
+	return result, nil
+}
+
+func boostedScores[T Numeric, S Index](rows [][]float64, initial, learningRate float64, trees []*DecisionTreeRegressor[T, S]) []float64 {
+	scores := make([]float64, len(rows))
+	for n := range scores {
+		scores[n] = initial
+	}
+
+	for _, tree := range trees {
+		for n, update := range tree.predictRows(rows) {
+			scores[n] += learningRate * update
+		}
+	}
+
+	return scores
+}
+
+// GradientBoostingClassifier is a binary classifier trained on the log loss.
+// Targets are 0/1 tensors, matching LogisticRegressionModel, Classify and
+// GenerateConfusionMatrix.
+type GradientBoostingClassifier[T Numeric, S Index] struct {
+	NumEstimators		S
+	LearningRate		T
+	MaxDepth		S
+	MinSamplesLeaf		S
+	Subsample		T
+	Seed			int64
+	InitialPrediction	T
+	Trees			[]*DecisionTreeRegressor[T, S]
+	TrainLoss		[]T
+	NumFeatures		S
+}
+
+func InitGradientBoostingClassifier[T Numeric, S Index](
+	numEstimators S,
+	learningRate T,
+	maxDepth S) (*GradientBoostingClassifier[T, S], error) {
+
+	subsample := 1.0
+
+	model := &GradientBoostingClassifier[T, S] {
+		NumEstimators:	numEstimators,
+		LearningRate:	learningRate,
+		MaxDepth:	maxDepth,
+		MinSamplesLeaf:	1,
+		Subsample:	T(subsample),
+	}
+
+	err := model.settings().validate()
+	if err != nil {
+		return &GradientBoostingClassifier[T, S]{}, err
+	}
+
+	return model, nil
+}
+
+func (model *GradientBoostingClassifier[T, S]) settings() gradientBoostingSettings[T, S] {
+	return gradientBoostingSettings[T, S]{model.NumEstimators, model.LearningRate, model.MaxDepth, model.MinSamplesLeaf, model.Subsample, nil}
+}
+
+func (model *GradientBoostingClassifier[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	settings := model.settings()
+	err := settings.validate()
+	if err != nil {
+		return err
+	}
+	settings.rng = newSeededRand(model.Seed)
+
+	if len(features.Shape) != 2 {
+		return errors.New("Gradient boosting requires a 2D feature tensor")
+	}
+
+	rows, err := treeRows(features, features.Shape[1])
+	if err != nil {
+		return err
+	}
+
+	numSamples := len(rows)
+	if numSamples == 0 || len(targets.Data) != numSamples {
+		return fmt.Errorf("Expecting %v targets, got %v", numSamples, len(targets.Data))
+	}
+
+	y := make([]float64, numSamples)
+	positives := 0.0
+	for n := range y {
+		y[n] = float64(targets.Data[n])
+		if y[n] != 0 && y[n] != 1 {
+			return errors.New("GradientBoostingClassifier expects target values to be 1 or 0")
+		}
+		positives += y[n]
+	}
+
+	// start from the log-odds of the positive class, clipped so a single
+	// class does not produce an infinite prior
+	prior := math.Min(math.Max(positives / float64(numSamples), logEpsilon), 1.0 - logEpsilon)
+	initial := math.Log(prior / (1.0 - prior))
+
+	logits := make([]float64, numSamples)
+	for n := range logits {
+		logits[n] = initial
+	}
+
+	residuals := make([]float64, numSamples)
+	learningRate := float64(model.LearningRate)
+
+	logitTensor, err := InitTensor[T, S]([]S{S(numSamples), 1})
+	if err != nil {
+		return err
+	}
+
+	model.NumFeatures = features.Shape[1]
+	model.InitialPrediction = T(initial)
+	model.Trees = make([]*DecisionTreeRegressor[T, S], 0, model.NumEstimators)
+	model.TrainLoss = make([]T, 0, model.NumEstimators)
+
+	for stage := S(0); stage < model.NumEstimators; stage++ {
+		for n := range residuals {
+			residuals[n] = y[n] - stableSigmoid(logits[n])
+		}
+
+		tree := &DecisionTreeRegressor[T, S] {
+			MaxDepth:	model.MaxDepth,
+			MinSamplesLeaf:	model.MinSamplesLeaf,
+			Seed:		settings.rng.Int63() + 1,
+		}
+
+		samples := settings.stageSamples(numSamples)
+		err = tree.fitTargets(features, residuals, samples)
+		if err != nil {
+			return fmt.Errorf("Fitting stage %v failed: %v", stage, err)
+		}
+
+		// replace each leaf's mean residual with a Newton step on the log
+		// loss: sum(residual) / sum(p * (1 - p))
+		numerators := make(map[*TreeNode]float64)
+		denominators := make(map[*TreeNode]float64)
+		for _, sample := range samples {
+			leaf := tree.Root.find(rows[sample])
+			p := stableSigmoid(logits[sample])
+			numerators[leaf] += residuals[sample]
+			denominators[leaf] += p * (1.0 - p)
+		}
+
+		for leaf, numerator := range numerators {
+			denominator := denominators[leaf]
+			if denominator < 1e-12 {
+				leaf.Value = 0
+				continue
+			}
+			leaf.Value = numerator / denominator
+		}
+
+		for n, update := range tree.predictRows(rows) {
+			logits[n] += learningRate * update
+			logitTensor.Data[n] = T(logits[n])
+		}
+
+		loss, err := BinaryCrossEntropyWithLogits(logitTensor, targets)
+		if err != nil {
+			return err
+		}
+
+		model.Trees = append(model.Trees, tree)
+		model.TrainLoss = append(model.TrainLoss, loss)
+	}
+
+	return nil
+}
+
+// PredictProba returns the [N, 1] probability of the positive class.
+func (model *GradientBoostingClassifier[T, S]) PredictProba(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(model.Trees) == 0 {
+		return &Tensor[T, S]{}, errors.New("GradientBoostingClassifier must be fitted (call Fit)")
+	}
+
+	rows, err := treeRows(features, model.NumFeatures)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	logits := boostedScores(rows, float64(model.InitialPrediction), float64(model.LearningRate), model.Trees)
+
+	result, err := InitTensor[T, S]([]S{S(len(rows)), 1})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, val := range logits {
+		result.Data[n] = T(stableSigmoid(val))
+	}
+
+	return result, nil
+}
+
+func (model *GradientBoostingClassifier[T, S]) Predict(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	probabilities, err := model.PredictProba(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	threshold := 0.5
+	return Classify(probabilities, T(threshold))
+}
@@ -0,0 +1,143 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGradientBoostingRegressorFit(t *testing.T) {
+	features, targets := forestRegressionData()
+
+	model, err := InitGradientBoostingRegressor[float64, uint64](100, 0.1, 3)
+	if err != nil {
+		t.Fatalf("InitGradientBoostingRegressor failed: %v\n", err)
+	}
+	model.Seed = 1
+
+	err = model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("GradientBoostingRegressor Fit failed: %v\n", err)
+	}
+
+	for n := 1; n < len(model.TrainLoss); n++ {
+		if model.TrainLoss[n] > model.TrainLoss[n - 1] + 1e-12 {
+			t.Fatalf("Training loss increased at stage %v: %v -> %v", n, model.TrainLoss[n - 1], model.TrainLoss[n])
+		}
+	}
+
+	predictions, err := model.Predict(features)
+	if err != nil {
+		t.Fatalf("GradientBoostingRegressor Predict failed: %v\n", err)
+	}
+
+	r2, err := R2Score(targets, predictions)
+	if err != nil {
+		t.Fatalf("R2Score failed: %v\n", err)
+	}
+
+	if r2 < 0.97 {
+		t.Errorf("Expected training R2 above 0.97, got %v", r2)
+	}
+}
+
+func TestGradientBoostingRegressorSubsample(t *testing.T) {
+	features, targets := forestRegressionData()
+
+	var predictions [2]*Tensor[float64, uint64]
+	for run := range predictions {
+		model, _ := InitGradientBoostingRegressor[float64, uint64](30, 0.1, 3)
+		model.Subsample = 0.5
+		model.Seed = 9
+
+		err := model.Fit(features, targets)
+		if err != nil {
+			t.Fatalf("GradientBoostingRegressor Fit failed: %v\n", err)
+		}
+
+		predictions[run], _ = model.Predict(features)
+	}
+
+	for n := range predictions[0].Data {
+		if predictions[0].Data[n] != predictions[1].Data[n] {
+			t.Fatalf("Seeded subsampled models differ at row %v", n)
+		}
+	}
+}
+
+func TestGradientBoostingClassifierFit(t *testing.T) {
+	// XOR-like quadrants are not linearly separable
+	features, _ := InitTensor64(40, 2)
+	targets, _ := InitTensor64(40, 1)
+
+	for n := 0; n < 40; n++ {
+		x := float64(n % 10) - 4.5
+		y := float64(n / 10) * 3.0 - 4.5 + float64(n % 3) * 0.1
+		features.Data[n * 2] = x
+		features.Data[n * 2 + 1] = y
+		if x * y > 0 {
+			targets.Data[n] = 1.0
+		}
+	}
+
+	model, err := InitGradientBoostingClassifier[float64, uint64](50, 0.3, 2)
+	if err != nil {
+		t.Fatalf("InitGradientBoostingClassifier failed: %v\n", err)
+	}
+	model.Seed = 2
+
+	err = model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("GradientBoostingClassifier Fit failed: %v\n", err)
+	}
+
+	if model.TrainLoss[len(model.TrainLoss) - 1] >= model.TrainLoss[0] {
+		t.Errorf("Expected the log loss to decrease, got %v", model.TrainLoss)
+	}
+
+	probabilities, err := model.PredictProba(features)
+	if err != nil {
+		t.Fatalf("GradientBoostingClassifier PredictProba failed: %v\n", err)
+	}
+
+	for _, p := range probabilities.Data {
+		if p < 0 || p > 1 || math.IsNaN(p) {
+			t.Fatalf("Probability out of range: %v", p)
+		}
+	}
+
+	predictions, err := model.Predict(features)
+	if err != nil {
+		t.Fatalf("GradientBoostingClassifier Predict failed: %v\n", err)
+	}
+
+	matrix, err := GenerateConfusionMatrix(targets, predictions)
+	if err != nil {
+		t.Fatalf("GenerateConfusionMatrix failed: %v\n", err)
+	}
+
+	if matrix.FalsePositives + matrix.FalseNegatives != 0 {
+		t.Errorf("Expected a perfect training fit, got %+v", matrix)
+	}
+}
+
+func TestGradientBoostingErrors(t *testing.T) {
+	_, err := InitGradientBoostingRegressor[float64, uint64](0, 0.1, 3)
+	if err == nil {
+		t.Errorf("Expected an error for zero estimators")
+	}
+
+	_, err = InitGradientBoostingClassifier[float64, uint64](10, 0, 3)
+	if err == nil {
+		t.Errorf("Expected an error for a zero learning rate")
+	}
+
+	model, _ := InitGradientBoostingClassifier[float64, uint64](10, 0.1, 3)
+	features, _ := InitTensor64(2, 1)
+	targets, _ := InitTensor64(2, 1)
+	targets.Data[1] = 2.0
+
+	err = model.Fit(features, targets)
+	if err == nil {
+		t.Errorf("Expected an error for non-binary targets")
+	}
+}
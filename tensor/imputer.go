@@ -0,0 +1,159 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type ImputeStrategy int
+
+const (
+	MeanStrategy ImputeStrategy = iota
+	MedianStrategy
+	MostFrequentStrategy
+	ConstantStrategy
+)
+
+// SimpleImputer replaces NaN cells with a per-feature statistic computed from
+// the values that are present during Fit. The result passes Valid() as long
+// as the input holds no infinities.
+type SimpleImputer[T Numeric, S Index] struct {
+	Strategy	ImputeStrategy
+	FillValue	T
+	Statistics	[]T
+}
+
+func InitSimpleImputer[T Numeric, S Index](strategy ImputeStrategy) (*SimpleImputer[T, S], error) {
+	if strategy < MeanStrategy || strategy > ConstantStrategy {
+		return &SimpleImputer[T, S]{}, fmt.Errorf("Unknown impute strategy %v", strategy)
+	}
+
+	return &SimpleImputer[T, S]{Strategy: strategy}, nil
+}
+
+// observedValues returns the sorted values of a column that are not NaN.
+func observedValues(column []float64) []float64 {
+	observed := make([]float64, 0, len(column))
+	for _, val := range column {
+		if !math.IsNaN(val) {
+			observed = append(observed, val)
+		}
+	}
+
+	sort.Float64s(observed)
+	return observed
+}
+
+// mostFrequent returns the most common of the sorted values, preferring the
+// smallest on ties.
+func mostFrequent(sorted []float64) float64 {
+	best := sorted[0]
+	bestCount := 0
+
+	for start := 0; start < len(sorted); {
+		end := start
+		for end < len(sorted) && sorted[end] == sorted[start] {
+			end++
+		}
+
+		if end - start > bestCount {
+			best = sorted[start]
+			bestCount = end - start
+		}
+		start = end
+	}
+
+	return best
+}
+
+func (imputer *SimpleImputer[T, S]) Fit(features *Tensor[T, S]) error {
+	if len(features.Shape) != 2 {
+		return errors.New("SimpleImputer requires a 2D tensor")
+	}
+
+	numFeatures := features.Shape[1]
+	imputer.Statistics = make([]T, numFeatures)
+
+	switch imputer.Strategy {
+	case ConstantStrategy:
+		for m := range imputer.Statistics {
+			imputer.Statistics[m] = imputer.FillValue
+		}
+		return nil
+	case MeanStrategy:
+		means, err := features.NanMeanAxis(0)
+		if err != nil {
+			return err
+		}
+
+		for m, val := range means.Data {
+			if math.IsNaN(float64(val)) {
+				return fmt.Errorf("Feature %v has no values to impute from", m)
+			}
+		}
+
+		copy(imputer.Statistics, means.Data)
+		return nil
+	case MedianStrategy, MostFrequentStrategy:
+	default:
+		return fmt.Errorf("Unknown impute strategy %v", imputer.Strategy)
+	}
+
+	columns, err := featureColumns(features)
+	if err != nil {
+		return err
+	}
+
+	for m, column := range columns {
+		observed := observedValues(column)
+		if len(observed) == 0 {
+			return fmt.Errorf("Feature %v has no values to impute from", m)
+		}
+
+		if imputer.Strategy == MedianStrategy {
+			imputer.Statistics[m] = T(quantile(observed, 0.5))
+		} else {
+			imputer.Statistics[m] = T(mostFrequent(observed))
+		}
+	}
+
+	return nil
+}
+
+// Transform returns a copy of input with every NaN replaced by the statistic
+// of its feature.
+func (imputer *SimpleImputer[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(imputer.Statistics) == 0 {
+		return &Tensor[T, S]{}, errors.New("SimpleImputer must be fitted (call Fit)")
+	}
+
+	if len(input.Shape) != 2 {
+		return &Tensor[T, S]{}, errors.New("SimpleImputer requires a 2D tensor")
+	}
+
+	numFeatures := input.Shape[1]
+	if numFeatures != S(len(imputer.Statistics)) {
+		return &Tensor[T, S]{}, fmt.Errorf("Expecting %v features, got %v", len(imputer.Statistics), numFeatures)
+	}
+
+	data, err := input.elements()
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	result, err := InitTensor[T, S](input.Shape)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, val := range data {
+		if math.IsNaN(float64(val)) {
+			val = imputer.Statistics[S(n) % numFeatures]
+		}
+		result.Data[n] = val
+	}
+
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimpleImputer(t *testing.T) {
+	nan := math.NaN()
+
+	input, _ := InitTensor64(5, 2)
+	input.Data = []float64 {
+		1.0, 7.0,
+		nan, 7.0,
+		3.0, nan,
+		10.0, 2.0,
+		nan, 3.0,
+	}
+
+	expected := map[ImputeStrategy][]float64 {
+		MeanStrategy:		{14.0 / 3.0, 19.0 / 4.0},
+		MedianStrategy:		{3.0, 5.0},
+		MostFrequentStrategy:	{1.0, 7.0},
+		ConstantStrategy:	{-1.0, -1.0},
+	}
+
+	for strategy, statistics := range expected {
+		imputer, err := InitSimpleImputer[float64, uint64](strategy)
+		if err != nil {
+			t.Fatalf("InitSimpleImputer failed: %v", err)
+		}
+		imputer.FillValue = -1.0
+
+		imputed, err := FitTransform[float64, uint64](imputer, input)
+		if err != nil {
+			t.Fatalf("Strategy %v failed: %v", strategy, err)
+		}
+
+		assertClose(t, "Statistics", imputer.Statistics, statistics)
+
+		if !imputed.Valid() {
+			t.Errorf("Strategy %v left NaNs behind: %v", strategy, imputed.Data)
+		}
+
+		if imputed.Data[2] != statistics[0] || imputed.Data[5] != statistics[1] || imputed.Data[0] != 1.0 {
+			t.Errorf("Strategy %v filled unexpected values: %v", strategy, imputed.Data)
+		}
+	}
+
+	// the input itself is left untouched
+	if !math.IsNaN(input.Data[2]) {
+		t.Errorf("Transform modified its input")
+	}
+
+	empty, _ := InitTensor64(2, 1)
+	empty.Data = []float64{nan, nan}
+
+	imputer, _ := InitSimpleImputer[float64, uint64](MedianStrategy)
+	err := imputer.Fit(empty)
+	if err == nil {
+		t.Errorf("Expected an error for a feature with no values")
+	}
+
+	_, err = InitSimpleImputer[float64, uint64](ImputeStrategy(9))
+	if err == nil {
+		t.Errorf("Expected an error for an unknown strategy")
+	}
+}
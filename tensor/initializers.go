@@ -0,0 +1,245 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// newSeededRand returns a generator for seed, or one seeded from the clock
+// when seed is 0.
+func newSeededRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return rand.New(rand.NewSource(seed))
+}
+
+// The random constructors draw from r, so a model can share one seeded
+// generator across all of its tensors. A nil r uses a generator seeded from
+// the clock.
+func orSeededRand(r *rand.Rand) *rand.Rand {
+	if r == nil {
+		return newSeededRand(0)
+	}
+
+	return r
+}
+
+// fillRandom initializes a tensor of the given shape with draws from sample.
+func fillRandom[T Numeric, S Index](shape []S, sample func() float64) (*Tensor[T, S], error) {
+	t, err := InitTensor[T, S](shape)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n := range t.Data {
+		t.Data[n] = T(sample())
+	}
+
+	return t, nil
+}
+
+// InitUniformTensor draws every element uniformly from [low, high).
+func InitUniformTensor[T Numeric, S Index](shape []S, low, high float64, r *rand.Rand) (*Tensor[T, S], error) {
+	if low > high {
+		return &Tensor[T, S]{}, fmt.Errorf("Uniform range must not be decreasing, got [%v, %v)", low, high)
+	}
+
+	r = orSeededRand(r)
+	return fillRandom[T, S](shape, func() float64 {
+		return low + r.Float64() * (high - low)
+	})
+}
+
+// InitNormalTensor draws every element from a normal distribution.
+func InitNormalTensor[T Numeric, S Index](shape []S, mean, stdDev float64, r *rand.Rand) (*Tensor[T, S], error) {
+	if stdDev < 0 {
+		return &Tensor[T, S]{}, fmt.Errorf("Standard deviation must be non-negative, got %v", stdDev)
+	}
+
+	r = orSeededRand(r)
+	return fillRandom[T, S](shape, func() float64 {
+		return mean + r.NormFloat64() * stdDev
+	})
+}
+
+// truncatedNormalBound is how many standard deviations from the mean
+// InitTruncatedNormalTensor allows before drawing again.
+const truncatedNormalBound = 2.0
+
+// InitTruncatedNormalTensor draws from a normal distribution, redrawing any
+// value more than two standard deviations from the mean, which keeps large
+// initial weights from saturating activations.
+func InitTruncatedNormalTensor[T Numeric, S Index](shape []S, mean, stdDev float64, r *rand.Rand) (*Tensor[T, S], error) {
+	if stdDev < 0 {
+		return &Tensor[T, S]{}, fmt.Errorf("Standard deviation must be non-negative, got %v", stdDev)
+	}
+
+	r = orSeededRand(r)
+	return fillRandom[T, S](shape, func() float64 {
+		for {
+			z := r.NormFloat64()
+			if math.Abs(z) <= truncatedNormalBound {
+				return mean + z * stdDev
+			}
+		}
+	})
+}
+
+// InitBernoulliTensor sets every element to 1 with probability p and to 0
+// otherwise, as in a dropout mask.
+func InitBernoulliTensor[T Numeric, S Index](shape []S, p float64, r *rand.Rand) (*Tensor[T, S], error) {
+	if p < 0 || p > 1 {
+		return &Tensor[T, S]{}, fmt.Errorf("Bernoulli probability must be in [0, 1], got %v", p)
+	}
+
+	r = orSeededRand(r)
+	return fillRandom[T, S](shape, func() float64 {
+		if r.Float64() < p {
+			return 1.0
+		}
+		return 0.0
+	})
+}
+
+type Initializer int
+
+const (
+	ZerosInitializer Initializer = iota
+	OnesInitializer
+	XavierUniformInitializer
+	XavierNormalInitializer
+	HeUniformInitializer
+	HeNormalInitializer
+	LeCunUniformInitializer
+	LeCunNormalInitializer
+)
+
+// InitWeightTensor creates a [fanIn, fanOut] weight matrix, the layout used by
+// DenseLayer and the linear models, with the named initializer:
+//
+//	Xavier (Glorot)	variance 2 / (fanIn + fanOut), suited to sigmoid and tanh
+//	He		variance 2 / fanIn, suited to ReLU
+//	LeCun		variance 1 / fanIn, suited to SELU and linear layers
+//
+// The uniform variants draw from [-limit, limit) with limit = sqrt(3 * variance)
+// and the normal variants use the truncated normal.
+func InitWeightTensor[T Numeric, S Index](shape []S, initializer Initializer, r *rand.Rand) (*Tensor[T, S], error) {
+	if len(shape) != 2 || shape[0] == 0 || shape[1] == 0 {
+		return &Tensor[T, S]{}, errors.New("Weight initialization requires a non-empty [fanIn, fanOut] shape")
+	}
+
+	fanIn := float64(shape[0])
+	fanOut := float64(shape[1])
+
+	var variance float64
+	switch initializer {
+	case ZerosInitializer:
+		return InitTensor[T, S](shape)
+	case OnesInitializer:
+		return Full[T, S](shape, T(1.0))
+	case XavierUniformInitializer, XavierNormalInitializer:
+		variance = 2.0 / (fanIn + fanOut)
+	case HeUniformInitializer, HeNormalInitializer:
+		variance = 2.0 / fanIn
+	case LeCunUniformInitializer, LeCunNormalInitializer:
+		variance = 1.0 / fanIn
+	default:
+		return &Tensor[T, S]{}, fmt.Errorf("Unknown initializer %v", initializer)
+	}
+
+	switch initializer {
+	case XavierNormalInitializer, HeNormalInitializer, LeCunNormalInitializer:
+		// truncating at two standard deviations shrinks the spread, so widen
+		// it to keep the intended variance
+		const truncatedStdDev = 0.87962566103423978
+		return InitTruncatedNormalTensor[T, S](shape, 0.0, math.Sqrt(variance) / truncatedStdDev, r)
+	}
+
+	limit := math.Sqrt(3.0 * variance)
+	return InitUniformTensor[T, S](shape, -limit, limit, r)
+}
+
+// Full creates a tensor with every element set to val.
+func Full[T Numeric, S Index](shape []S, val T) (*Tensor[T, S], error) {
+	t, err := InitTensor[T, S](shape)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n := range t.Data {
+		t.Data[n] = val
+	}
+
+	return t, nil
+}
+
+// Eye creates the [n, n] identity matrix.
+func Eye[T Numeric, S Index](n S) (*Tensor[T, S], error) {
+	t, err := InitTensor[T, S]([]S{n, n})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for i := S(0); i < n; i++ {
+		t.Data[i * n + i] = T(1.0)
+	}
+
+	return t, nil
+}
+
+// Arange creates the 1D tensor start, start + step, ... up to but excluding
+// stop.
+func Arange[T Numeric, S Index](start, stop, step T) (*Tensor[T, S], error) {
+	if step == 0 {
+		return &Tensor[T, S]{}, errors.New("Arange step must not be zero")
+	}
+
+	count := math.Ceil((float64(stop) - float64(start)) / float64(step))
+	if count < 0 {
+		count = 0
+	}
+
+	t, err := InitTensor[T, S]([]S{S(count)})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n := range t.Data {
+		t.Data[n] = start + T(n) * step
+	}
+
+	return t, nil
+}
+
+// Linspace creates a 1D tensor of num evenly spaced values from start to stop,
+// including both ends.
+func Linspace[T Numeric, S Index](start, stop T, num S) (*Tensor[T, S], error) {
+	t, err := InitTensor[T, S]([]S{num})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	if num == 1 {
+		t.Data[0] = start
+		return t, nil
+	}
+
+	low := float64(start)
+	step := (float64(stop) - low) / float64(num - 1)
+	for n := range t.Data {
+		val := low + float64(n) * step
+		t.Data[n] = T(val)
+	}
+
+	// land exactly on stop despite rounding in the steps
+	if num > 1 {
+		t.Data[num - 1] = stop
+	}
+
+	return t, nil
+}
@@ -0,0 +1,169 @@
+package tensor
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// sampleMoments returns the mean and variance of values.
+func sampleMoments(values []float64) (float64, float64) {
+	mean := 0.0
+	for _, val := range values {
+		mean += val
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, val := range values {
+		variance += (val - mean) * (val - mean)
+	}
+
+	return mean, variance / float64(len(values))
+}
+
+func TestRandomDistributions(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	shape := []uint64{200, 100}
+
+	uniform, err := InitUniformTensor[float64, uint64](shape, 2.0, 4.0, r)
+	if err != nil {
+		t.Fatalf("InitUniformTensor failed: %v", err)
+	}
+	for _, val := range uniform.Data {
+		if val < 2.0 || val >= 4.0 {
+			t.Fatalf("Uniform value %v outside [2, 4)", val)
+		}
+	}
+	mean, variance := sampleMoments(uniform.Data)
+	if math.Abs(mean - 3.0) > 0.02 || math.Abs(variance - 1.0 / 3.0) > 0.02 {
+		t.Errorf("Unexpected uniform moments: mean %v variance %v", mean, variance)
+	}
+
+	normal, _ := InitNormalTensor[float64, uint64](shape, -1.0, 2.0, r)
+	mean, variance = sampleMoments(normal.Data)
+	if math.Abs(mean + 1.0) > 0.05 || math.Abs(variance - 4.0) > 0.15 {
+		t.Errorf("Unexpected normal moments: mean %v variance %v", mean, variance)
+	}
+
+	truncated, _ := InitTruncatedNormalTensor[float64, uint64](shape, 0.0, 1.0, r)
+	for _, val := range truncated.Data {
+		if math.Abs(val) > 2.0 {
+			t.Fatalf("Truncated normal value %v beyond two standard deviations", val)
+		}
+	}
+
+	bernoulli, _ := InitBernoulliTensor[float64, uint64](shape, 0.3, r)
+	ones := 0
+	for _, val := range bernoulli.Data {
+		if val != 0.0 && val != 1.0 {
+			t.Fatalf("Bernoulli value %v is not 0 or 1", val)
+		}
+		ones += int(val)
+	}
+	if math.Abs(float64(ones) / 20000.0 - 0.3) > 0.02 {
+		t.Errorf("Expected about 30%% ones, got %v of 20000", ones)
+	}
+
+	_, err = InitBernoulliTensor[float64, uint64](shape, 1.5, r)
+	if err == nil {
+		t.Errorf("Expected an error for a probability above 1")
+	}
+
+	_, err = InitNormalTensor[float64, uint64](shape, 0.0, -1.0, r)
+	if err == nil {
+		t.Errorf("Expected an error for a negative standard deviation")
+	}
+
+	// the same seed gives the same tensor
+	first, _ := InitNormalTensor[float64, uint64](shape, 0.0, 1.0, rand.New(rand.NewSource(7)))
+	second, _ := InitNormalTensor[float64, uint64](shape, 0.0, 1.0, rand.New(rand.NewSource(7)))
+	if !reflect.DeepEqual(first.Data, second.Data) {
+		t.Errorf("Expected seeded draws to repeat")
+	}
+}
+
+func TestWeightInitializers(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	shape := []uint64{300, 200}
+
+	expectedVariance := map[Initializer]float64 {
+		XavierUniformInitializer:	2.0 / 500.0,
+		XavierNormalInitializer:	2.0 / 500.0,
+		HeUniformInitializer:		2.0 / 300.0,
+		HeNormalInitializer:		2.0 / 300.0,
+		LeCunUniformInitializer:	1.0 / 300.0,
+		LeCunNormalInitializer:		1.0 / 300.0,
+	}
+
+	for initializer, expected := range expectedVariance {
+		weights, err := InitWeightTensor[float64, uint64](shape, initializer, r)
+		if err != nil {
+			t.Fatalf("Initializer %v failed: %v", initializer, err)
+		}
+
+		mean, variance := sampleMoments(weights.Data)
+		if math.Abs(mean) > 0.01 || math.Abs(variance - expected) / expected > 0.05 {
+			t.Errorf("Initializer %v: mean %v variance %v, expected variance %v", initializer, mean, variance, expected)
+		}
+	}
+
+	zeros, _ := InitWeightTensor[float64, uint64](shape, ZerosInitializer, r)
+	ones, _ := InitWeightTensor[float64, uint64](shape, OnesInitializer, r)
+	if zeros.Data[17] != 0.0 || ones.Data[17] != 1.0 {
+		t.Errorf("Unexpected constant initializers: %v %v", zeros.Data[17], ones.Data[17])
+	}
+
+	_, err := InitWeightTensor[float64, uint64]([]uint64{3}, HeNormalInitializer, r)
+	if err == nil {
+		t.Errorf("Expected an error for a 1D weight shape")
+	}
+
+	_, err = InitWeightTensor[float64, uint64](shape, Initializer(42), r)
+	if err == nil {
+		t.Errorf("Expected an error for an unknown initializer")
+	}
+}
+
+func TestDeterministicConstructors(t *testing.T) {
+	eye, _ := Eye[float64, uint64](3)
+	if !reflect.DeepEqual(eye.Data, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}) {
+		t.Errorf("Unexpected identity matrix: %v", eye.Data)
+	}
+
+	full, _ := Full[float64, uint64]([]uint64{2, 2}, 7.5)
+	if !reflect.DeepEqual(full.Data, []float64{7.5, 7.5, 7.5, 7.5}) {
+		t.Errorf("Unexpected Full tensor: %v", full.Data)
+	}
+
+	arange, _ := Arange[float64, uint64](0.0, 1.0, 0.25)
+	if !reflect.DeepEqual(arange.Data, []float64{0.0, 0.25, 0.5, 0.75}) {
+		t.Errorf("Unexpected Arange tensor: %v", arange.Data)
+	}
+
+	countdown, _ := Arange[int, uint64](5, 0, -2)
+	if !reflect.DeepEqual(countdown.Data, []int{5, 3, 1}) {
+		t.Errorf("Unexpected descending Arange tensor: %v", countdown.Data)
+	}
+
+	empty, _ := Arange[float64, uint64](3.0, 1.0, 1.0)
+	if len(empty.Data) != 0 {
+		t.Errorf("Expected an empty Arange when stop precedes start, got %v", empty.Data)
+	}
+
+	_, err := Arange[float64, uint64](0.0, 1.0, 0.0)
+	if err == nil {
+		t.Errorf("Expected an error for a zero step")
+	}
+
+	linspace, _ := Linspace[float64, uint64](-1.0, 1.0, 5)
+	if !reflect.DeepEqual(linspace.Data, []float64{-1.0, -0.5, 0.0, 0.5, 1.0}) || linspace.Shape[0] != 5 {
+		t.Errorf("Unexpected Linspace tensor: %v", linspace.Data)
+	}
+
+	single, _ := Linspace[float64, uint64](2.0, 3.0, 1)
+	if !reflect.DeepEqual(single.Data, []float64{2.0}) {
+		t.Errorf("Unexpected single-point Linspace: %v", single.Data)
+	}
+}
@@ -0,0 +1,121 @@
+package tensor
+
+// The methods below update the receiver instead of allocating a result, and
+// the Into functions write into a preallocated dst, so a training loop can
+// allocate its buffers once up front. The receiver or dst must be contiguous.
+// The operands must have the same shape, as for Add, Subtract and Hadamard,
+// but may be views such as a transpose.
+
+func (t *Tensor[T, S]) AddInPlace(other *Tensor[T, S]) error {
+	return AddInto(t, t, other)
+}
+
+func (t *Tensor[T, S]) SubtractInPlace(other *Tensor[T, S]) error {
+	return SubtractInto(t, t, other)
+}
+
+func (t *Tensor[T, S]) HadamardInPlace(other *Tensor[T, S]) error {
+	return HadamardInto(t, t, other)
+}
+
+// ScaleInPlace multiplies every element by scalar.
+func (t *Tensor[T, S]) ScaleInPlace(scalar T) error {
+	return MulScalarInto(t, t, scalar)
+}
+
+func (t *Tensor[T, S]) AddScalarInPlace(scalar T) error {
+	return AddScalarInto(t, t, scalar)
+}
+
+// AxpyInPlace adds alpha * x to the receiver, the update at the heart of
+// every gradient step.
+func (t *Tensor[T, S]) AxpyInPlace(alpha T, x *Tensor[T, S]) error {
+	srcT, srcX, out, err := binaryIntoSlices(t, t, x)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range srcX {
+		out[n] = srcT[n] + alpha * val
+	}
+
+	return nil
+}
+
+func AddInto[T Numeric, S Index](dst, a, b *Tensor[T, S]) error {
+	srcA, srcB, out, err := binaryIntoSlices(dst, a, b)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range srcA {
+		out[n] = val + srcB[n]
+	}
+
+	return nil
+}
+
+func SubtractInto[T Numeric, S Index](dst, a, b *Tensor[T, S]) error {
+	srcA, srcB, out, err := binaryIntoSlices(dst, a, b)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range srcA {
+		out[n] = val - srcB[n]
+	}
+
+	return nil
+}
+
+func HadamardInto[T Numeric, S Index](dst, a, b *Tensor[T, S]) error {
+	srcA, srcB, out, err := binaryIntoSlices(dst, a, b)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range srcA {
+		out[n] = val * srcB[n]
+	}
+
+	return nil
+}
+
+func MulScalarInto[T Numeric, S Index](dst, input *Tensor[T, S], scalar T) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = val * scalar
+	}
+
+	return nil
+}
+
+func AddScalarInto[T Numeric, S Index](dst, input *Tensor[T, S], scalar T) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = val + scalar
+	}
+
+	return nil
+}
+
+func SigmoidInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = T(stableSigmoid(float64(val)))
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package tensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInPlaceOps(t *testing.T) {
+	a, _ := InitTensor64(2, 2)
+	a.Data = []float64{1.0, 2.0, 3.0, 4.0}
+	b, _ := InitTensor64(2, 2)
+	b.Data = []float64{0.5, -1.0, 2.0, 0.0}
+
+	err := a.AddInPlace(b)
+	if err != nil || !reflect.DeepEqual(a.Data, []float64{1.5, 1.0, 5.0, 4.0}) {
+		t.Errorf("Unexpected AddInPlace: %v (%v)", a.Data, err)
+	}
+
+	a.SubtractInPlace(b)
+	if !reflect.DeepEqual(a.Data, []float64{1.0, 2.0, 3.0, 4.0}) {
+		t.Errorf("Unexpected SubtractInPlace: %v", a.Data)
+	}
+
+	a.HadamardInPlace(b)
+	if !reflect.DeepEqual(a.Data, []float64{0.5, -2.0, 6.0, 0.0}) {
+		t.Errorf("Unexpected HadamardInPlace: %v", a.Data)
+	}
+
+	a.ScaleInPlace(2.0)
+	a.AddScalarInPlace(1.0)
+	if !reflect.DeepEqual(a.Data, []float64{2.0, -3.0, 13.0, 1.0}) {
+		t.Errorf("Unexpected ScaleInPlace and AddScalarInPlace: %v", a.Data)
+	}
+
+	a.AxpyInPlace(-2.0, b)
+	if !reflect.DeepEqual(a.Data, []float64{1.0, -1.0, 9.0, 1.0}) {
+		t.Errorf("Unexpected AxpyInPlace: %v", a.Data)
+	}
+
+	// the other operand may be a transposed view
+	transposed, _ := b.Transpose()
+	a.AddInPlace(transposed)
+	if !reflect.DeepEqual(a.Data, []float64{1.5, 1.0, 8.0, 1.0}) {
+		t.Errorf("Unexpected AddInPlace with a view: %v", a.Data)
+	}
+
+	wrongShape, _ := InitTensor64(3, 1)
+	err = a.AddInPlace(wrongShape)
+	if err == nil {
+		t.Errorf("Expected an error for mismatched shapes")
+	}
+
+	// a [1, N] target against [N, 1] predictions is a shape mismatch even
+	// though the element counts agree
+	predictions, _ := InitTensor64(4, 1)
+	targets, _ := InitTensor64(1, 4)
+	err = predictions.SubtractInPlace(targets)
+	if err == nil {
+		t.Errorf("Expected an error for [1 4] targets against [4 1] predictions")
+	}
+
+	err = predictions.AxpyInPlace(2.0, targets)
+	if err == nil {
+		t.Errorf("Expected AxpyInPlace to reject mismatched shapes")
+	}
+
+	err = transposed.ScaleInPlace(2.0)
+	if err == nil {
+		t.Errorf("Expected an error for a non-contiguous receiver")
+	}
+}
+
+func TestIntoOps(t *testing.T) {
+	a, _ := InitTensor64(2, 3)
+	a.Data = []float64{1, 2, 3, 4, 5, 6}
+	b, _ := InitTensor64(3, 2)
+	b.Data = []float64{1, 0, 0, 1, 1, 1}
+
+	dst, _ := InitTensor64(2, 2)
+	dst.Data = []float64{9, 9, 9, 9}
+	err := DotInto(dst, a, b)
+	if err != nil || !reflect.DeepEqual(dst.Data, []float64{4, 5, 10, 11}) {
+		t.Errorf("Unexpected DotInto: %v (%v)", dst.Data, err)
+	}
+
+	// DotInto matches Dot for a transposed operand
+	aT, _ := a.Transpose()
+	expected, _ := aT.Dot(a)
+	square, _ := InitTensor64(3, 3)
+	DotInto(square, aT, a)
+	if !reflect.DeepEqual(square.Data, expected.Data) {
+		t.Errorf("DotInto %v differs from Dot %v", square.Data, expected.Data)
+	}
+
+	err = DotInto(square, a, b)
+	if err == nil {
+		t.Errorf("Expected an error for a destination of the wrong shape")
+	}
+
+	// writing the product over an operand would corrupt it mid-computation
+	identity, _ := Eye[float64, uint64](2)
+	err = DotInto(dst, dst, identity)
+	if err == nil {
+		t.Errorf("Expected an error for a destination that is also an operand")
+	}
+
+	row, _ := dst.GetBatchSlice(1, 1)
+	err = DotInto(row, row, identity)
+	if err == nil {
+		t.Errorf("Expected an error for a destination sharing storage with an operand")
+	}
+
+	c, _ := InitTensor64(2, 2)
+	c.Data = []float64{1, 1, 2, 2}
+	sum, _ := InitTensor64(2, 2)
+	AddInto(sum, dst, c)
+	SubtractInto(sum, sum, c)
+	if !reflect.DeepEqual(sum.Data, dst.Data) {
+		t.Errorf("Unexpected AddInto and SubtractInto: %v", sum.Data)
+	}
+
+	HadamardInto(sum, dst, c)
+	if !reflect.DeepEqual(sum.Data, []float64{4, 5, 20, 22}) {
+		t.Errorf("Unexpected HadamardInto: %v", sum.Data)
+	}
+
+	MulScalarInto(sum, c, 3.0)
+	AddScalarInto(sum, sum, -1.0)
+	if !reflect.DeepEqual(sum.Data, []float64{2, 2, 5, 5}) {
+		t.Errorf("Unexpected MulScalarInto and AddScalarInto: %v", sum.Data)
+	}
+
+	c.Data = []float64{0, 0, 0, 0}
+	SigmoidInto(sum, c)
+	if !reflect.DeepEqual(sum.Data, []float64{0.5, 0.5, 0.5, 0.5}) {
+		t.Errorf("Unexpected SigmoidInto: %v", sum.Data)
+	}
+}
+
+func TestAddPenaltyGradient(t *testing.T) {
+	weights, _ := InitTensor64(3, 1)
+	weights.Data = []float64{2.0, -1.0, 0.5}
+	reg := Regularization[float64]{Penalty: L2Penalty, Strength: 0.1}
+
+	gradient, _ := InitTensor64(3, 1)
+	gradient.Data = []float64{1.0, 1.0, 1.0}
+	err := AddPenaltyGradient(reg, weights, gradient, true)
+	if err != nil {
+		t.Fatalf("AddPenaltyGradient failed: %v", err)
+	}
+
+	expected, _ := PenaltyGradient(reg, weights, true)
+	for n, val := range gradient.Data {
+		if val != 1.0 + expected.Data[n] {
+			t.Errorf("Row %v: got %v, expected %v", n, val, 1.0 + expected.Data[n])
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+type KMeans[T Numeric, S Index] struct {
+	K		S
+	MaxIterations	S
+	NumInit		S
+	Tolerance	T
+	NumWorkers	int
+	Seed		int64
+	Centroids	*Tensor[T, S]
+	Labels		[]S
+	Inertia		T
+	NumIterations	S
+}
+
+func InitKMeans[T Numeric, S Index](k S, maxIterations S, numInit S) (*KMeans[T, S], error) {
+	if k == 0 {
+		return &KMeans[T, S]{}, errors.New("KMeans requires at least one cluster")
+	}
+
+	if numInit == 0 {
+		return &KMeans[T, S]{}, errors.New("KMeans requires at least one initialization")
+	}
+
+	tolerance := 1e-4
+
+	model := &KMeans[T, S] {
+		K:		k,
+		MaxIterations:	maxIterations,
+		NumInit:	numInit,
+		Tolerance:	T(tolerance),
+		NumWorkers:	runtime.NumCPU(),
+	}
+
+	return model, nil
+}
+
+func (model *KMeans[T, S]) Fit(features *Tensor[T, S]) error {
+	if len(features.Shape) != 2 {
+		return errors.New("KMeans requires a 2D feature tensor")
+	}
+
+	numSamples := features.Shape[0]
+	if numSamples < model.K {
+		return fmt.Errorf("KMeans cannot find %v clusters in %v samples", model.K, numSamples)
+	}
+
+	points, err := features.Contiguous()
+	if err != nil {
+		return err
+	}
+
+	r := newSeededRand(model.Seed)
+
+	bestInertia := math.Inf(1)
+
+	for run := S(0); run < model.NumInit; run++ {
+		centroids, err := kMeansPlusPlus(points, model.K, r)
+		if err != nil {
+			return err
+		}
+
+		labels, inertia, iterations, err := model.lloyd(points, centroids)
+		if err != nil {
+			return err
+		}
+
+		if inertia < bestInertia {
+			bestInertia = inertia
+			model.Centroids = centroids
+			model.Labels = labels
+			model.Inertia = T(inertia)
+			model.NumIterations = iterations
+		}
+	}
+
+	return nil
+}
+
+// kMeansPlusPlus seeds the first centroid uniformly and every following one
+// with probability proportional to its squared distance from the nearest
+// centroid chosen so far.
+func kMeansPlusPlus[T Numeric, S Index](points *Tensor[T, S], k S, r *rand.Rand) (*Tensor[T, S], error) {
+	numSamples := points.Shape[0]
+	numFeatures := points.Shape[1]
+
+	centroids, err := InitTensor[T, S]([]S{k, numFeatures})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	first := S(r.Intn(int(numSamples)))
+	copy(centroids.Data[:numFeatures], points.Data[first * numFeatures: (first + 1) * numFeatures])
+
+	closest := make([]float64, numSamples)
+	for n := S(0); n < numSamples; n++ {
+		closest[n] = squaredDistance(points.Data[n * numFeatures: (n + 1) * numFeatures], centroids.Data[:numFeatures])
+	}
+
+	for c := S(1); c < k; c++ {
+		total := 0.0
+		for _, d := range closest {
+			total += d
+		}
+
+		chosen := S(0)
+		if total == 0 {
+			// every point coincides with a centroid already; any choice will do
+			chosen = S(r.Intn(int(numSamples)))
+		} else {
+			target := r.Float64() * total
+			cumulative := 0.0
+			for n := S(0); n < numSamples; n++ {
+				cumulative += closest[n]
+				if cumulative >= target && closest[n] > 0 {
+					chosen = n
+					break
+				}
+			}
+		}
+
+		centroid := centroids.Data[c * numFeatures: (c + 1) * numFeatures]
+		copy(centroid, points.Data[chosen * numFeatures: (chosen + 1) * numFeatures])
+
+		for n := S(0); n < numSamples; n++ {
+			d := squaredDistance(points.Data[n * numFeatures: (n + 1) * numFeatures], centroid)
+			closest[n] = math.Min(closest[n], d)
+		}
+	}
+
+	return centroids, nil
+}
+
+func squaredDistance[T Numeric](a, b []T) float64 {
+	sum := 0.0
+	for n := range a {
+		diff := float64(a[n]) - float64(b[n])
+		sum += diff * diff
+	}
+
+	return sum
+}
+
+func (model *KMeans[T, S]) lloyd(points *Tensor[T, S], centroids *Tensor[T, S]) ([]S, float64, S, error) {
+	numSamples := points.Shape[0]
+	numFeatures := points.Shape[1]
+	k := centroids.Shape[0]
+
+	labels := make([]S, numSamples)
+	distances := make([]float64, numSamples)
+	inertia := 0.0
+
+	sums := make([]float64, k * numFeatures)
+	counts := make([]S, k)
+
+	iterations := S(0)
+	for iterations < model.MaxIterations {
+		inertia = model.assign(points, centroids, labels, distances)
+		iterations++
+
+		for n := range sums {
+			sums[n] = 0
+		}
+		for n := range counts {
+			counts[n] = 0
+		}
+
+		for n := S(0); n < numSamples; n++ {
+			label := labels[n]
+			counts[label]++
+			for m := S(0); m < numFeatures; m++ {
+				sums[label * numFeatures + m] += float64(points.Data[n * numFeatures + m])
+			}
+		}
+
+		shift := 0.0
+		for c := S(0); c < k; c++ {
+			centroid := centroids.Data[c * numFeatures: (c + 1) * numFeatures]
+
+			if counts[c] == 0 {
+				// move an empty cluster onto the point that is currently
+				// worst served by its centroid
+				farthest := S(0)
+				for n := S(1); n < numSamples; n++ {
+					if distances[n] > distances[farthest] {
+						farthest = n
+					}
+				}
+
+				shift += squaredDistance(centroid, points.Data[farthest * numFeatures: (farthest + 1) * numFeatures])
+				copy(centroid, points.Data[farthest * numFeatures: (farthest + 1) * numFeatures])
+				distances[farthest] = 0
+				continue
+			}
+
+			for m := S(0); m < numFeatures; m++ {
+				updated := sums[c * numFeatures + m] / float64(counts[c])
+				diff := updated - float64(centroid[m])
+				shift += diff * diff
+				centroid[m] = T(updated)
+			}
+		}
+
+		if shift <= float64(model.Tolerance) {
+			break
+		}
+	}
+
+	inertia = model.assign(points, centroids, labels, distances)
+
+	if math.IsNaN(inertia) || math.IsInf(inertia, 0) {
+		return nil, 0, iterations, errors.New("NaN or Inf introduced during KMeans iterations")
+	}
+
+	return labels, inertia, iterations, nil
+}
+
+// assign labels every point with its nearest centroid, splitting the rows
+// across NumWorkers goroutines, and returns the resulting inertia.
+func (model *KMeans[T, S]) assign(points *Tensor[T, S], centroids *Tensor[T, S], labels []S, distances []float64) float64 {
+	numSamples := int(points.Shape[0])
+	numFeatures := points.Shape[1]
+	k := centroids.Shape[0]
+
+	numWorkers := max(1, min(model.NumWorkers, numSamples))
+	chunkSize := (numSamples + numWorkers - 1) / numWorkers
+
+	partialInertia := make([]float64, numWorkers)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < numWorkers; worker++ {
+		start := worker * chunkSize
+		end := min(start + chunkSize, numSamples)
+		if start >= end {
+			continue
+		}
+
+		wg.Add(1)
+		go func(worker, start, end int) {
+			defer wg.Done()
+
+			for n := start; n < end; n++ {
+				point := points.Data[S(n) * numFeatures: S(n + 1) * numFeatures]
+
+				best := S(0)
+				bestDistance := math.Inf(1)
+				for c := S(0); c < k; c++ {
+					d := squaredDistance(point, centroids.Data[c * numFeatures: (c + 1) * numFeatures])
+					if d < bestDistance {
+						best = c
+						bestDistance = d
+					}
+				}
+
+				labels[n] = best
+				distances[n] = bestDistance
+				partialInertia[worker] += bestDistance
+			}
+		}(worker, start, end)
+	}
+	wg.Wait()
+
+	inertia := 0.0
+	for _, val := range partialInertia {
+		inertia += val
+	}
+
+	return inertia
+}
+
+func (model *KMeans[T, S]) Predict(features *Tensor[T, S]) ([]S, error) {
+	if model.Centroids == nil {
+		return nil, errors.New("KMeans must be fitted (call Fit)")
+	}
+
+	if len(features.Shape) != 2 || features.Shape[1] != model.Centroids.Shape[1] {
+		return nil, fmt.Errorf("Predict expects shape [N, %v], got %v", model.Centroids.Shape[1], features.Shape)
+	}
+
+	points, err := features.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]S, points.Shape[0])
+	distances := make([]float64, points.Shape[0])
+	model.assign(points, model.Centroids, labels, distances)
+
+	return labels, nil
+}
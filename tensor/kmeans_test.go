@@ -0,0 +1,140 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func kMeansClusterData() *Tensor[float64, uint64] {
+	features, _ := InitTensor64(9, 2)
+	features.Data = []float64{
+		0.0, 0.0, 0.2, 0.1, -0.1, 0.2,
+		10.0, 10.0, 10.2, 9.9, 9.8, 10.1,
+		-10.0, 10.0, -9.8, 10.2, -10.1, 9.9,
+	}
+
+	return features
+}
+
+func TestKMeansFit(t *testing.T) {
+	features := kMeansClusterData()
+
+	model, err := InitKMeans[float64, uint64](3, 100, 5)
+	if err != nil {
+		t.Fatalf("InitKMeans failed: %v\n", err)
+	}
+	model.Seed = 42
+
+	err = model.Fit(features)
+	if err != nil {
+		t.Fatalf("KMeans Fit failed: %v\n", err)
+	}
+
+	if model.Centroids.Shape[0] != 3 || model.Centroids.Shape[1] != 2 {
+		t.Errorf("Unexpected centroid shape: %v", model.Centroids.Shape)
+	}
+
+	for cluster := 0; cluster < 3; cluster++ {
+		first := model.Labels[cluster * 3]
+		for n := 1; n < 3; n++ {
+			if model.Labels[cluster * 3 + n] != first {
+				t.Errorf("Points of one cluster received different labels: %v", model.Labels)
+			}
+		}
+	}
+
+	if model.Labels[0] == model.Labels[3] || model.Labels[0] == model.Labels[6] || model.Labels[3] == model.Labels[6] {
+		t.Errorf("Separate clusters share a label: %v", model.Labels)
+	}
+
+	expectedInertia := 0.0
+	for cluster := 0; cluster < 3; cluster++ {
+		label := model.Labels[cluster * 3]
+		for n := 0; n < 3; n++ {
+			row := uint64(cluster * 3 + n)
+			for m := uint64(0); m < 2; m++ {
+				diff := features.Data[row * 2 + m] - model.Centroids.Data[label * 2 + m]
+				expectedInertia += diff * diff
+			}
+		}
+	}
+
+	if math.Abs(model.Inertia - expectedInertia) > 1e-9 {
+		t.Errorf("Unexpected inertia: got %v, expected %v", model.Inertia, expectedInertia)
+	}
+
+	if model.Inertia > 1.0 {
+		t.Errorf("Inertia too large for well separated clusters: %v", model.Inertia)
+	}
+}
+
+func TestKMeansPredict(t *testing.T) {
+	features := kMeansClusterData()
+
+	model, _ := InitKMeans[float64, uint64](3, 100, 3)
+	model.NumWorkers = 2
+
+	err := model.Fit(features)
+	if err != nil {
+		t.Fatalf("KMeans Fit failed: %v\n", err)
+	}
+
+	query, _ := InitTensor64(3, 2)
+	query.Data = []float64{9.0, 9.0, -9.0, 9.0, 1.0, -1.0}
+
+	labels, err := model.Predict(query)
+	if err != nil {
+		t.Fatalf("KMeans Predict failed: %v\n", err)
+	}
+
+	expected := []uint64{model.Labels[3], model.Labels[6], model.Labels[0]}
+	for n := range expected {
+		if labels[n] != expected[n] {
+			t.Errorf("Unexpected predicted clusters: got %v, expected %v", labels, expected)
+			break
+		}
+	}
+}
+
+func TestKMeansPlusPlusDistinctSeeds(t *testing.T) {
+	features := kMeansClusterData()
+
+	model, _ := InitKMeans[float64, uint64](3, 0, 1)
+	model.Seed = 7
+
+	// with no Lloyd iterations the centroids are the k-means++ seeds, which
+	// must be three distinct training points
+	err := model.Fit(features)
+	if err != nil {
+		t.Fatalf("KMeans Fit failed: %v\n", err)
+	}
+
+	for a := uint64(0); a < 3; a++ {
+		for b := a + 1; b < 3; b++ {
+			d := squaredDistance(model.Centroids.Data[a * 2: a * 2 + 2], model.Centroids.Data[b * 2: b * 2 + 2])
+			if d == 0 {
+				t.Errorf("k-means++ chose the same point twice: %v", model.Centroids.Data)
+			}
+		}
+	}
+}
+
+func TestKMeansValidation(t *testing.T) {
+	_, err := InitKMeans[float64, uint64](0, 10, 1)
+	if err == nil {
+		t.Errorf("Zero clusters accepted by InitKMeans")
+	}
+
+	model, _ := InitKMeans[float64, uint64](5, 10, 1)
+	features, _ := InitTensor64(3, 2)
+
+	err = model.Fit(features)
+	if err == nil {
+		t.Errorf("More clusters than samples accepted by Fit")
+	}
+
+	_, err = model.Predict(features)
+	if err == nil {
+		t.Errorf("Predict before Fit did not fail")
+	}
+}
@@ -1,15 +1,52 @@
 package tensor
 
 import (
-	"sort"
 	"errors"
 	"fmt"
+	"math"
+	"runtime"
+)
+
+type NeighborAlgorithm int
+
+const (
+	AutoAlgorithm NeighborAlgorithm = iota
+	BruteForceAlgorithm
+	KDTreeAlgorithm
+	BallTreeAlgorithm
+)
+
+// KD-trees stop pruning effectively as dimensions grow, so AutoAlgorithm
+// only builds one for low-dimensional data and otherwise falls back to
+// brute force.
+const autoKDTreeMaxDimensions = 16
+
+const defaultLeafSize = 30
+
+type VoteWeighting int
+
+const (
+	UniformWeighting VoteWeighting = iota
+	DistanceWeighting
+	KernelWeighting
 )
 
 type KNN[T Numeric, S Index] struct {
 	K			S
 	TrainingFeatures	*Tensor[T, S]
 	TrainingLabels		[]string
+	Algorithm		NeighborAlgorithm
+	LeafSize		int
+	Metric			DistanceMetric
+	MinkowskiP		float64
+	CustomDistance		DistanceFunc
+	FeatureWeights		[]T
+	Weighting		VoteWeighting
+	Bandwidth		float64
+	NumWorkers		int
+	index			spatialIndex
+	rows			[][]float64
+	distance		DistanceFunc
 }
 
 type Neighbor[T Numeric] struct {
@@ -17,36 +54,247 @@ type Neighbor[T Numeric] struct {
 	Label		string
 }
 
+func InitKNN[T Numeric, S Index](k S, algorithm NeighborAlgorithm) (*KNN[T, S], error) {
+	if k == 0 {
+		return &KNN[T, S]{}, errors.New("KNN requires K of at least 1")
+	}
+
+	if algorithm < AutoAlgorithm || algorithm > BallTreeAlgorithm {
+		return &KNN[T, S]{}, fmt.Errorf("Unknown neighbor algorithm %v", algorithm)
+	}
+
+	model := &KNN[T, S] {
+		K:		k,
+		Algorithm:	algorithm,
+		LeafSize:	defaultLeafSize,
+		Metric:		EuclideanMetric,
+		MinkowskiP:	2,
+		NumWorkers:	runtime.NumCPU(),
+	}
+
+	return model, nil
+}
+
+// Fit stores the training data and, depending on Algorithm, builds a KD-tree
+// or ball tree over it. A KNN whose fields are set directly, without Fit,
+// uses brute force.
+//
+// FeatureWeights, if set, multiply every feature of both the training rows
+// and the queries before distances are measured. Call Fit again after
+// changing the metric or the weights. Cosine and custom metrics
+// always use brute force, since the spatial indexes rely on properties only
+// the Minkowski family of metrics is known to have.
+func (model *KNN[T, S]) Fit(features *Tensor[T, S], labels []string) error {
+	if len(features.Shape) != 2 {
+		return errors.New("KNN requires a 2D feature tensor")
+	}
+
+	if S(len(labels)) != features.Shape[0] {
+		return fmt.Errorf("Expecting %v labels, got %v", features.Shape[0], len(labels))
+	}
+
+	model.TrainingLabels = labels
+	return model.fitFeatures(features)
+}
+
+// fitFeatures caches the weighted training rows and builds the spatial index
+// chosen by Algorithm. It is shared by KNN and KNNRegressor.
+func (model *KNN[T, S]) fitFeatures(features *Tensor[T, S]) error {
+	if len(features.Shape) != 2 {
+		return errors.New("KNN requires a 2D feature tensor")
+	}
+
+	model.TrainingFeatures = features
+	model.index = nil
+	model.rows = nil
+	model.distance = nil
+
+	rows, distance, err := model.prepare()
+	if err != nil {
+		return err
+	}
+
+	model.rows = rows
+	model.distance = distance
+
+	algorithm := model.Algorithm
+	if algorithm == AutoAlgorithm {
+		algorithm = BruteForceAlgorithm
+		if features.Shape[1] <= autoKDTreeMaxDimensions && features.Shape[0] > S(model.leafSize()) && supportsSpatialIndex(model.Metric) {
+			algorithm = KDTreeAlgorithm
+		}
+	}
+
+	if algorithm != BruteForceAlgorithm && !supportsSpatialIndex(model.Metric) {
+		return fmt.Errorf("Metric %v requires BruteForceAlgorithm or AutoAlgorithm", model.Metric)
+	}
+
+	if algorithm == BruteForceAlgorithm || features.Shape[0] == 0 {
+		return nil
+	}
+
+	switch algorithm {
+	case KDTreeAlgorithm:
+		model.index = buildKDTree(rows, model.leafSize(), distance)
+	case BallTreeAlgorithm:
+		model.index = buildBallTree(rows, model.leafSize(), distance)
+	default:
+		return fmt.Errorf("Unknown neighbor algorithm %v", model.Algorithm)
+	}
+
+	return nil
+}
+
+func (model *KNN[T, S]) leafSize() int {
+	if model.LeafSize <= 0 {
+		return defaultLeafSize
+	}
+
+	return model.LeafSize
+}
+
+// prepare returns the weighted training rows and the distance function,
+// reusing the ones cached by Fit when available.
+func (model *KNN[T, S]) prepare() ([][]float64, DistanceFunc, error) {
+	if model.rows != nil {
+		return model.rows, model.distance, nil
+	}
+
+	if model.TrainingFeatures == nil {
+		return nil, nil, errors.New("KNN must be fitted (call Fit)")
+	}
+
+	distance, err := resolveDistance(model.Metric, model.MinkowskiP, model.CustomDistance)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := matrixToFloat64(model.TrainingFeatures)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(model.FeatureWeights) > 0 {
+		if len(model.FeatureWeights) != int(model.TrainingFeatures.Shape[1]) {
+			return nil, nil, fmt.Errorf("Expecting %v feature weights, got %v", model.TrainingFeatures.Shape[1], len(model.FeatureWeights))
+		}
+
+		for _, row := range rows {
+			model.weigh(row)
+		}
+	}
+
+	return rows, distance, nil
+}
+
+func (model *KNN[T, S]) weigh(row []float64) {
+	for n, weight := range model.FeatureWeights {
+		row[n] *= float64(weight)
+	}
+}
+
+// queryRow converts a [1, F] query into a weighted float64 row.
+func (model *KNN[T, S]) queryRow(queryPoint *Tensor[T, S]) ([]float64, error) {
+	numFeatures := model.TrainingFeatures.Shape[1]
+
+	numElements := S(1)
+	for _, dim := range queryPoint.Shape {
+		numElements *= dim
+	}
+
+	if len(queryPoint.Shape) == 0 || numElements != numFeatures {
+		return nil, fmt.Errorf("Expecting a single query with %v features, got shape %v", numFeatures, queryPoint.Shape)
+	}
+
+	query, err := queryPoint.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+
+	point := make([]float64, numFeatures)
+	for n := range point {
+		point[n] = float64(query.Data[n])
+	}
+	model.weigh(point)
+
+	return point, nil
+}
+
 func FindKNearestLabels[T Numeric, S Index](knn *KNN[T, S], queryPoint *Tensor[T, S]) ([]Neighbor[T], error) {
-	
-	distances, err := EuclideanDistances(queryPoint, knn.TrainingFeatures)
+	candidates, err := knn.nearest(queryPoint)
 	if err != nil {
 		return nil, err
 	}
 
-	numSamples := S(len(distances.Data))
-	if numSamples != S(len(knn.TrainingLabels)) {
+	if knn.TrainingFeatures.Shape[0] != S(len(knn.TrainingLabels)) {
 		return nil, errors.New("distance tensor != label array length")
 	}
 
-	neighbors := make([]Neighbor[T], numSamples)
+	return knn.neighbors(candidates), nil
+}
+
+// nearest returns the K nearest training rows to a single query, closest first.
+func (knn *KNN[T, S]) nearest(queryPoint *Tensor[T, S]) ([]neighborCandidate, error) {
+	if knn.rows == nil && knn.Metric == EuclideanMetric && len(knn.FeatureWeights) == 0 {
+		return knn.nearestEuclidean(queryPoint)
+	}
+
+	rows, distance, err := knn.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	point, err := knn.queryRow(queryPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	k := int(min(knn.K, S(len(rows))))
+
+	if knn.index != nil {
+		return knn.index.query(point, k), nil
+	}
+
+	best := newBoundedHeap(k)
+	for n, row := range rows {
+		best.offer(n, distance(point, row))
+	}
+
+	return best.sorted(), nil
+}
+
+// nearestEuclidean serves KNNs assembled from their fields without Fit,
+// measuring Euclidean distances directly on the tensors.
+func (knn *KNN[T, S]) nearestEuclidean(queryPoint *Tensor[T, S]) ([]neighborCandidate, error) {
+	if knn.TrainingFeatures == nil {
+		return nil, errors.New("KNN must be fitted (call Fit)")
+	}
+
+	distances, err := EuclideanDistances(queryPoint, knn.TrainingFeatures)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep only the K best in a bounded heap rather than sorting every distance
+	numSamples := S(len(distances.Data))
+	best := newBoundedHeap(int(min(knn.K, numSamples)))
 	for n := S(0); n < numSamples; n++ {
-		neighbors[n] = Neighbor[T] {
-			Distance: 	distances.Data[n],
-			Label:		knn.TrainingLabels[n],
-		}
+		best.offer(int(n), float64(distances.Data[n]))
 	}
 
-	sort.Slice(neighbors, func(i, j int) bool {
-		return neighbors[i].Distance < neighbors[j].Distance
-	})
+	return best.sorted(), nil
+}
 
-	kNearest := knn.K
-	if kNearest > numSamples {
-		kNearest = numSamples
+func (knn *KNN[T, S]) neighbors(candidates []neighborCandidate) []Neighbor[T] {
+	neighbors := make([]Neighbor[T], len(candidates))
+	for n, candidate := range candidates {
+		neighbors[n] = Neighbor[T] {
+			Distance:	T(candidate.distance),
+			Label:		knn.TrainingLabels[candidate.index],
+		}
 	}
 
-	return neighbors[:kNearest], nil
+	return neighbors
 }
 
 func MajorityVote[T Numeric](neighbors []Neighbor[T]) (string, error) {
@@ -87,15 +335,142 @@ func MajorityVote[T Numeric](neighbors []Neighbor[T]) (string, error) {
 	return predictedLabel, nil
 }
 
+// neighborWeights returns the vote of each neighbor. DistanceWeighting uses
+// 1/d, letting exact matches outvote everything else; KernelWeighting uses a
+// Gaussian kernel whose bandwidth defaults to the distance of the farthest
+// neighbor.
+func neighborWeights(distances []float64, weighting VoteWeighting, bandwidth float64) ([]float64, error) {
+	weights := make([]float64, len(distances))
+
+	switch weighting {
+	case UniformWeighting:
+		for n := range weights {
+			weights[n] = 1.0
+		}
+	case DistanceWeighting:
+		exactMatches := false
+		for _, d := range distances {
+			if d == 0 {
+				exactMatches = true
+			}
+		}
+
+		for n, d := range distances {
+			if exactMatches {
+				if d == 0 {
+					weights[n] = 1.0
+				}
+				continue
+			}
+			weights[n] = 1.0 / d
+		}
+	case KernelWeighting:
+		if bandwidth <= 0 {
+			for _, d := range distances {
+				bandwidth = math.Max(bandwidth, d)
+			}
+		}
+
+		for n, d := range distances {
+			if bandwidth == 0 {
+				weights[n] = 1.0
+				continue
+			}
+			weights[n] = math.Exp(-d * d / (2.0 * bandwidth * bandwidth))
+		}
+	default:
+		return nil, fmt.Errorf("Unknown vote weighting %v", weighting)
+	}
+
+	return weights, nil
+}
+
+// VoteShares returns the weighted share of the vote won by each label among
+// the neighbors. The shares sum to 1.
+func VoteShares[T Numeric](neighbors []Neighbor[T], weighting VoteWeighting, bandwidth float64) (map[string]T, error) {
+	if len(neighbors) == 0 {
+		return nil, errors.New("Neighbors list is empty")
+	}
+
+	distances := make([]float64, len(neighbors))
+	for n, neighbor := range neighbors {
+		distances[n] = float64(neighbor.Distance)
+	}
+
+	weights, err := neighborWeights(distances, weighting, bandwidth)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	votes := make(map[string]float64)
+	for n, neighbor := range neighbors {
+		votes[neighbor.Label] += weights[n]
+		total += weights[n]
+	}
+
+	shares := make(map[string]T, len(votes))
+	for label, vote := range votes {
+		if total == 0 {
+			// every kernel weight underflowed; fall back to counting
+			vote = 0
+			for _, neighbor := range neighbors {
+				if neighbor.Label == label {
+					vote++
+				}
+			}
+			shares[label] = T(vote / float64(len(neighbors)))
+			continue
+		}
+		shares[label] = T(vote / total)
+	}
+
+	return shares, nil
+}
+
+// PredictProba returns the share of the (possibly weighted) vote won by each
+// label among the K nearest neighbors of a single query.
+func (model *KNN[T, S]) PredictProba(queryPoint *Tensor[T, S]) (map[string]T, error) {
+	neighbors, err := FindKNearestLabels(model, queryPoint)
+	if err != nil {
+		return nil, fmt.Errorf("FindKNearestNeighbors failed in PredictProba: %v", err)
+	}
+
+	return VoteShares(neighbors, model.Weighting, model.Bandwidth)
+}
+
 func (model *KNN[T, S]) Predict(queryPoint *Tensor[T, S]) (string, error) {
 	neighbors, err := FindKNearestLabels(model, queryPoint)
 	if err != nil {
 		return "", fmt.Errorf("FindKNearestNeighbors failed in Predict: %v", err)
 	}
 
-	prediction, err := MajorityVote(neighbors)
+	return model.vote(neighbors)
+}
+
+// vote picks a label from neighbors sorted by distance, using MajorityVote
+// for uniform weighting and the largest weighted share otherwise.
+func (model *KNN[T, S]) vote(neighbors []Neighbor[T]) (string, error) {
+	if model.Weighting == UniformWeighting {
+		prediction, err := MajorityVote(neighbors)
+		if err != nil {
+			return "", fmt.Errorf("Majority Vote failed in Predict: %v", err)
+		}
+
+		return prediction, nil
+	}
+
+	shares, err := VoteShares(neighbors, model.Weighting, model.Bandwidth)
 	if err != nil {
-		return "", fmt.Errorf("Majority Vote failed in Predict: %v", err)
+		return "", fmt.Errorf("Weighted vote failed in Predict: %v", err)
+	}
+
+	// neighbors are sorted by distance, so ties go to the label seen first
+	prediction := ""
+	for _, neighbor := range neighbors {
+		if prediction == "" || shares[neighbor.Label] > shares[prediction] {
+			prediction = neighbor.Label
+		}
 	}
 
 	return prediction, nil
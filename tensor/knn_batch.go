@@ -0,0 +1,217 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// PredictBatch scores every query up to this many rows at a time, which
+// bounds the distance matrix held by each worker to blockSize * N values.
+const batchQueryBlockSize = 64
+
+// queryRows converts a [Q, F] tensor of queries into weighted float64 rows.
+func (model *KNN[T, S]) queryRows(queries *Tensor[T, S]) ([][]float64, error) {
+	if model.TrainingFeatures == nil {
+		return nil, errors.New("KNN must be fitted (call Fit)")
+	}
+
+	numFeatures := model.TrainingFeatures.Shape[1]
+	if len(queries.Shape) != 2 || queries.Shape[1] != numFeatures {
+		return nil, fmt.Errorf("Expecting queries of shape [Q, %v], got %v", numFeatures, queries.Shape)
+	}
+
+	points, err := matrixToFloat64(queries)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, point := range points {
+		model.weigh(point)
+	}
+
+	return points, nil
+}
+
+func (model *KNN[T, S]) numWorkers() int {
+	if model.NumWorkers <= 0 {
+		return runtime.NumCPU()
+	}
+
+	return model.NumWorkers
+}
+
+// nearestBatch returns the K nearest training rows for every [Q, F] query, in
+// query order. Blocks of queries are spread over NumWorkers goroutines.
+func (model *KNN[T, S]) nearestBatch(queries *Tensor[T, S]) ([][]neighborCandidate, error) {
+	rows, distance, err := model.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := model.queryRows(queries)
+	if err != nil {
+		return nil, err
+	}
+
+	k := int(min(model.K, S(len(rows))))
+	results := make([][]neighborCandidate, len(points))
+
+	// without a spatial index, Euclidean distances for a whole block come from
+	// a single matrix product
+	var transposed *Tensor[float64, S]
+	var trainingNorms []float64
+	if model.index == nil && model.Metric == EuclideanMetric {
+		transposed, trainingNorms, err = normsAndMatrix[S](rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	numBlocks := (len(points) + batchQueryBlockSize - 1) / batchQueryBlockSize
+	numWorkers := max(1, min(model.numWorkers(), numBlocks))
+
+	jobs := make(chan int)
+	errs := make(chan error, numBlocks)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < numWorkers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for block := range jobs {
+				start := block * batchQueryBlockSize
+				end := min(start + batchQueryBlockSize, len(points))
+
+				switch {
+				case transposed != nil:
+					err := nearestByDot(points[start:end], transposed, trainingNorms, k, results[start:end])
+					if err != nil {
+						errs <- fmt.Errorf("Scoring queries %v to %v failed: %v", start, end, err)
+					}
+				case model.index != nil:
+					for n := start; n < end; n++ {
+						results[n] = model.index.query(points[n], k)
+					}
+				default:
+					for n := start; n < end; n++ {
+						best := newBoundedHeap(k)
+						for m, row := range rows {
+							best.offer(m, distance(points[n], row))
+						}
+						results[n] = best.sorted()
+					}
+				}
+			}
+		}()
+	}
+
+	for block := 0; block < numBlocks; block++ {
+		jobs <- block
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// normsAndMatrix packs the training rows into a transposed [F, N] tensor, so
+// Dot can walk it row by row, and returns it with the squared norm of every
+// row.
+func normsAndMatrix[S Index](rows [][]float64) (*Tensor[float64, S], []float64, error) {
+	numFeatures := 0
+	if len(rows) > 0 {
+		numFeatures = len(rows[0])
+	}
+
+	numSamples := len(rows)
+	matrix, err := InitTensor[float64, S]([]S{S(numFeatures), S(numSamples)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	norms := make([]float64, numSamples)
+	for n, row := range rows {
+		for m, val := range row {
+			matrix.Data[m * numSamples + n] = val
+		}
+		norms[n] = dotFloat64(row, row)
+	}
+
+	return matrix, norms, nil
+}
+
+// nearestByDot finds the k nearest training rows for each point using
+// ||a - b||^2 = ||a||^2 + ||b||^2 - 2a.b, so the cross terms for the whole
+// block come from one Dot against the [F, N] transposed training matrix.
+func nearestByDot[S Index](points [][]float64, transposed *Tensor[float64, S], trainingNorms []float64, k int, results [][]neighborCandidate) error {
+	numFeatures := int(transposed.Shape[0])
+
+	block, err := InitTensor[float64, S]([]S{S(len(points)), S(numFeatures)})
+	if err != nil {
+		return err
+	}
+
+	for n, point := range points {
+		copy(block.Data[n * numFeatures:], point)
+	}
+
+	cross, err := block.Dot(transposed)
+	if err != nil {
+		return err
+	}
+
+	numSamples := len(trainingNorms)
+	for n, point := range points {
+		pointNorm := dotFloat64(point, point)
+
+		best := newBoundedHeap(k)
+		limit := math.Inf(1)
+		crossRow := cross.Data[n * numSamples: (n + 1) * numSamples]
+		for m, crossTerm := range crossRow {
+			squared := pointNorm + trainingNorms[m] - 2.0 * crossTerm
+			if squared >= limit {
+				continue
+			}
+
+			// rounding can push the squared distance of near-identical rows
+			// slightly below zero
+			best.offer(m, math.Sqrt(math.Max(0.0, squared)))
+			limit = best.worst() * best.worst()
+		}
+
+		results[n] = best.sorted()
+	}
+
+	return nil
+}
+
+// PredictBatch predicts a label for every row of a [Q, F] tensor of queries,
+// returning them in query order.
+func (model *KNN[T, S]) PredictBatch(queries *Tensor[T, S]) ([]string, error) {
+	candidates, err := model.nearestBatch(queries)
+	if err != nil {
+		return nil, fmt.Errorf("Neighbor search failed in PredictBatch: %v", err)
+	}
+
+	if model.TrainingFeatures.Shape[0] != S(len(model.TrainingLabels)) {
+		return nil, errors.New("distance tensor != label array length")
+	}
+
+	predictions := make([]string, len(candidates))
+	for n, nearest := range candidates {
+		predictions[n], err = model.vote(model.neighbors(nearest))
+		if err != nil {
+			return nil, fmt.Errorf("Vote failed for query %v in PredictBatch: %v", n, err)
+		}
+	}
+
+	return predictions, nil
+}
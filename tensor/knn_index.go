@@ -0,0 +1,290 @@
+package tensor
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+type neighborCandidate struct {
+	index		int
+	distance	float64
+}
+
+// neighborHeap is a max-heap on distance, so the worst of the current
+// candidates is always at the top and can be replaced in O(log K).
+type neighborHeap []neighborCandidate
+
+func (h neighborHeap) Len() int			{ return len(h) }
+func (h neighborHeap) Less(i, j int) bool	{ return h[i].distance > h[j].distance }
+func (h neighborHeap) Swap(i, j int)		{ h[i], h[j] = h[j], h[i] }
+
+func (h *neighborHeap) Push(x any) {
+	*h = append(*h, x.(neighborCandidate))
+}
+
+func (h *neighborHeap) Pop() any {
+	old := *h
+	last := old[len(old) - 1]
+	*h = old[:len(old) - 1]
+	return last
+}
+
+// boundedHeap keeps the k nearest candidates offered to it.
+type boundedHeap struct {
+	k	int
+	items	neighborHeap
+}
+
+func newBoundedHeap(k int) *boundedHeap {
+	return &boundedHeap{k: k, items: make(neighborHeap, 0, k)}
+}
+
+func (b *boundedHeap) offer(index int, distance float64) {
+	if b.k == 0 {
+		return
+	}
+
+	if len(b.items) < b.k {
+		heap.Push(&b.items, neighborCandidate{index, distance})
+		return
+	}
+
+	if distance < b.items[0].distance {
+		b.items[0] = neighborCandidate{index, distance}
+		heap.Fix(&b.items, 0)
+	}
+}
+
+// worst is the distance a new candidate has to beat, or +Inf while the heap
+// still has room.
+func (b *boundedHeap) worst() float64 {
+	if len(b.items) < b.k {
+		return math.Inf(1)
+	}
+
+	return b.items[0].distance
+}
+
+func (b *boundedHeap) sorted() []neighborCandidate {
+	result := make([]neighborCandidate, len(b.items))
+	copy(result, b.items)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].distance < result[j].distance
+	})
+
+	return result
+}
+
+// spatialIndex answers k-nearest-neighbor queries against the training rows
+// it was built from. Candidate indices refer to those rows.
+type spatialIndex interface {
+	query(point []float64, k int) []neighborCandidate
+}
+
+// widestDimension returns the dimension with the largest spread over the
+// given rows, and that spread.
+func widestDimension(rows [][]float64, indices []int) (int, float64) {
+	numDims := len(rows[indices[0]])
+
+	bestDim := 0
+	bestSpread := -1.0
+	for d := 0; d < numDims; d++ {
+		low := math.Inf(1)
+		high := math.Inf(-1)
+		for _, index := range indices {
+			low = math.Min(low, rows[index][d])
+			high = math.Max(high, rows[index][d])
+		}
+
+		if high - low > bestSpread {
+			bestDim = d
+			bestSpread = high - low
+		}
+	}
+
+	return bestDim, bestSpread
+}
+
+// splitAtMedian orders indices along dim and returns the middle position.
+func splitAtMedian(rows [][]float64, indices []int, dim int) int {
+	sort.Slice(indices, func(i, j int) bool {
+		return rows[indices[i]][dim] < rows[indices[j]][dim]
+	})
+
+	return len(indices) / 2
+}
+
+type kdNode struct {
+	start, end	int
+	splitDim	int
+	splitValue	float64
+	left, right	*kdNode
+}
+
+// kdTree splits the space with axis-aligned planes through the median of the
+// widest dimension. Every point left of a split has a coordinate at most the
+// split value, so the gap to the plane bounds the distance to that side.
+type kdTree struct {
+	rows		[][]float64
+	indices		[]int
+	leafSize	int
+	distance	DistanceFunc
+	root		*kdNode
+}
+
+func buildKDTree(rows [][]float64, leafSize int, distance DistanceFunc) *kdTree {
+	tree := &kdTree {
+		rows:		rows,
+		indices:	allSamples(len(rows)),
+		leafSize:	max(1, leafSize),
+		distance:	distance,
+	}
+
+	tree.root = tree.build(0, len(rows))
+	return tree
+}
+
+func (tree *kdTree) build(start, end int) *kdNode {
+	node := &kdNode{start: start, end: end}
+	if end - start <= tree.leafSize {
+		return node
+	}
+
+	dim, spread := widestDimension(tree.rows, tree.indices[start:end])
+	if spread == 0 {
+		return node
+	}
+
+	mid := start + splitAtMedian(tree.rows, tree.indices[start:end], dim)
+
+	node.splitDim = dim
+	node.splitValue = tree.rows[tree.indices[mid]][dim]
+	node.left = tree.build(start, mid)
+	node.right = tree.build(mid, end)
+
+	return node
+}
+
+func (tree *kdTree) query(point []float64, k int) []neighborCandidate {
+	best := newBoundedHeap(k)
+	tree.search(tree.root, point, best)
+	return best.sorted()
+}
+
+func (tree *kdTree) search(node *kdNode, point []float64, best *boundedHeap) {
+	if node.left == nil {
+		for _, index := range tree.indices[node.start:node.end] {
+			best.offer(index, tree.distance(point, tree.rows[index]))
+		}
+		return
+	}
+
+	gap := point[node.splitDim] - node.splitValue
+
+	near, far := node.left, node.right
+	if gap > 0 {
+		near, far = node.right, node.left
+	}
+
+	tree.search(near, point, best)
+
+	if math.Abs(gap) < best.worst() {
+		tree.search(far, point, best)
+	}
+}
+
+type ballNode struct {
+	start, end	int
+	center		[]float64
+	radius		float64
+	left, right	*ballNode
+}
+
+// ballTree groups points into nested hyperspheres. By the triangle
+// inequality no point in a ball is closer than distance(center) - radius,
+// which prunes whole balls and holds up better than a KD-tree as the number
+// of dimensions grows.
+type ballTree struct {
+	rows		[][]float64
+	indices		[]int
+	leafSize	int
+	distance	DistanceFunc
+	root		*ballNode
+}
+
+func buildBallTree(rows [][]float64, leafSize int, distance DistanceFunc) *ballTree {
+	tree := &ballTree {
+		rows:		rows,
+		indices:	allSamples(len(rows)),
+		leafSize:	max(1, leafSize),
+		distance:	distance,
+	}
+
+	tree.root = tree.build(0, len(rows))
+	return tree
+}
+
+func (tree *ballTree) build(start, end int) *ballNode {
+	indices := tree.indices[start:end]
+	numDims := len(tree.rows[indices[0]])
+
+	center := make([]float64, numDims)
+	for _, index := range indices {
+		for d, val := range tree.rows[index] {
+			center[d] += val
+		}
+	}
+	for d := range center {
+		center[d] /= float64(len(indices))
+	}
+
+	radius := 0.0
+	for _, index := range indices {
+		radius = math.Max(radius, tree.distance(center, tree.rows[index]))
+	}
+
+	node := &ballNode{start: start, end: end, center: center, radius: radius}
+	if end - start <= tree.leafSize || radius == 0 {
+		return node
+	}
+
+	dim, _ := widestDimension(tree.rows, indices)
+	mid := start + splitAtMedian(tree.rows, indices, dim)
+
+	node.left = tree.build(start, mid)
+	node.right = tree.build(mid, end)
+
+	return node
+}
+
+func (tree *ballTree) query(point []float64, k int) []neighborCandidate {
+	best := newBoundedHeap(k)
+	tree.search(tree.root, point, tree.distance(point, tree.root.center), best)
+	return best.sorted()
+}
+
+func (tree *ballTree) search(node *ballNode, point []float64, centerDistance float64, best *boundedHeap) {
+	if centerDistance - node.radius >= best.worst() {
+		return
+	}
+
+	if node.left == nil {
+		for _, index := range tree.indices[node.start:node.end] {
+			best.offer(index, tree.distance(point, tree.rows[index]))
+		}
+		return
+	}
+
+	leftDistance := tree.distance(point, node.left.center)
+	rightDistance := tree.distance(point, node.right.center)
+
+	if leftDistance <= rightDistance {
+		tree.search(node.left, point, leftDistance, best)
+		tree.search(node.right, point, rightDistance, best)
+	} else {
+		tree.search(node.right, point, rightDistance, best)
+		tree.search(node.left, point, leftDistance, best)
+	}
+}
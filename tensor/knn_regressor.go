@@ -0,0 +1,155 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// KNNRegressor predicts numeric targets as the (possibly weighted) average of
+// the targets of the K nearest training rows. Neighbors holds the search
+// settings shared with KNN, such as K, Algorithm, Metric and Weighting.
+type KNNRegressor[T Numeric, S Index] struct {
+	Neighbors	*KNN[T, S]
+	TrainingTargets	*Tensor[T, S]
+}
+
+func InitKNNRegressor[T Numeric, S Index](k S, algorithm NeighborAlgorithm) (*KNNRegressor[T, S], error) {
+	neighbors, err := InitKNN[T, S](k, algorithm)
+	if err != nil {
+		return &KNNRegressor[T, S]{}, err
+	}
+
+	return &KNNRegressor[T, S]{Neighbors: neighbors}, nil
+}
+
+// Fit stores the training rows and their targets, which may be [N] or
+// [N, outputs].
+func (model *KNNRegressor[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	if model.Neighbors == nil {
+		return errors.New("KNNRegressor requires Neighbors settings (use InitKNNRegressor)")
+	}
+
+	if len(features.Shape) != 2 {
+		return errors.New("KNNRegressor requires a 2D feature tensor")
+	}
+
+	numSamples := features.Shape[0]
+	if len(targets.Shape) == 0 || targets.Shape[0] != numSamples || len(targets.Shape) > 2 {
+		return fmt.Errorf("Expecting targets of shape [%v] or [%v, outputs], got %v", numSamples, numSamples, targets.Shape)
+	}
+
+	contiguousTargets, err := targets.Contiguous()
+	if err != nil {
+		return err
+	}
+
+	err = model.Neighbors.fitFeatures(features)
+	if err != nil {
+		return err
+	}
+
+	model.TrainingTargets = contiguousTargets
+	return nil
+}
+
+func (model *KNNRegressor[T, S]) numOutputs() S {
+	if len(model.TrainingTargets.Shape) == 1 {
+		return 1
+	}
+
+	return model.TrainingTargets.Shape[1]
+}
+
+// Predict returns the [1, outputs] prediction for a single [1, F] query.
+func (model *KNNRegressor[T, S]) Predict(queryPoint *Tensor[T, S]) (*Tensor[T, S], error) {
+	if model.TrainingTargets == nil {
+		return &Tensor[T, S]{}, errors.New("KNNRegressor must be fitted (call Fit)")
+	}
+
+	candidates, err := model.Neighbors.nearest(queryPoint)
+	if err != nil {
+		return &Tensor[T, S]{}, fmt.Errorf("Neighbor search failed in Predict: %v", err)
+	}
+
+	numOutputs := model.numOutputs()
+	result, err := InitTensor[T, S]([]S{1, numOutputs})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	err = model.average(candidates, result.Data)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return result, nil
+}
+
+// average writes the weighted mean of the candidates' targets into dst.
+func (model *KNNRegressor[T, S]) average(candidates []neighborCandidate, dst []T) error {
+	if len(candidates) == 0 {
+		return errors.New("Neighbors list is empty")
+	}
+
+	distances := make([]float64, len(candidates))
+	for n, candidate := range candidates {
+		distances[n] = candidate.distance
+	}
+
+	weights, err := neighborWeights(distances, model.Neighbors.Weighting, model.Neighbors.Bandwidth)
+	if err != nil {
+		return err
+	}
+
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	// every kernel weight underflowed; fall back to a plain average
+	if total == 0 {
+		for n := range weights {
+			weights[n] = 1.0
+		}
+		total = float64(len(weights))
+	}
+
+	numOutputs := int(model.numOutputs())
+	for m := 0; m < numOutputs; m++ {
+		sum := 0.0
+		for n, candidate := range candidates {
+			sum += weights[n] * float64(model.TrainingTargets.Data[candidate.index * numOutputs + m])
+		}
+		dst[m] = T(sum / total)
+	}
+
+	return nil
+}
+
+// PredictBatch returns the [Q, outputs] predictions for a [Q, F] tensor of
+// queries, in query order.
+func (model *KNNRegressor[T, S]) PredictBatch(queries *Tensor[T, S]) (*Tensor[T, S], error) {
+	if model.TrainingTargets == nil {
+		return &Tensor[T, S]{}, errors.New("KNNRegressor must be fitted (call Fit)")
+	}
+
+	candidates, err := model.Neighbors.nearestBatch(queries)
+	if err != nil {
+		return &Tensor[T, S]{}, fmt.Errorf("Neighbor search failed in PredictBatch: %v", err)
+	}
+
+	numOutputs := model.numOutputs()
+	result, err := InitTensor[T, S]([]S{S(len(candidates)), numOutputs})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, nearest := range candidates {
+		err = model.average(nearest, result.Data[S(n) * numOutputs: S(n + 1) * numOutputs])
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+	}
+
+	return result, nil
+}
@@ -1,7 +1,9 @@
 package tensor
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -93,3 +95,580 @@ func TestTieBreaker(t *testing.T) {
 		t.Errorf("Unexpected result for Tie-Breaker. Got %v, expected %v", result, expected)
 	}
 }
+
+func randomKNNData(numSamples, numFeatures uint64, seed int64) (*Tensor[float64, uint64], []string) {
+	r := rand.New(rand.NewSource(seed))
+
+	features, _ := InitTensor64(numSamples, numFeatures)
+	for n := range features.Data {
+		features.Data[n] = r.Float64() * 10.0
+	}
+
+	// a few exact duplicates exercise zero-spread splits
+	for n := uint64(0); n < numFeatures; n++ {
+		features.Data[numFeatures + n] = features.Data[n]
+	}
+
+	labels := make([]string, numSamples)
+	for n := range labels {
+		labels[n] = fmt.Sprintf("label-%v", n)
+	}
+
+	return features, labels
+}
+
+func TestKNNSpatialIndexesMatchBruteForce(t *testing.T) {
+	for _, numFeatures := range []uint64{2, 5} {
+		features, labels := randomKNNData(500, numFeatures, int64(numFeatures))
+
+		brute, _ := InitKNN[float64, uint64](7, BruteForceAlgorithm)
+		err := brute.Fit(features, labels)
+		if err != nil {
+			t.Fatalf("Brute force Fit failed: %v\n", err)
+		}
+
+		for _, algorithm := range []NeighborAlgorithm{KDTreeAlgorithm, BallTreeAlgorithm} {
+			indexed, _ := InitKNN[float64, uint64](7, algorithm)
+			indexed.LeafSize = 8
+
+			err = indexed.Fit(features, labels)
+			if err != nil {
+				t.Fatalf("Fit failed for algorithm %v: %v\n", algorithm, err)
+			}
+
+			if indexed.index == nil {
+				t.Fatalf("Expected algorithm %v to build an index", algorithm)
+			}
+
+			queries, _ := randomKNNData(50, numFeatures, 99)
+			for q := uint64(0); q < 50; q++ {
+				query, _ := queries.GetBatchSlice(q, 1)
+
+				expected, err := FindKNearestLabels(brute, query)
+				if err != nil {
+					t.Fatalf("Brute force query failed: %v\n", err)
+				}
+
+				actual, err := FindKNearestLabels(indexed, query)
+				if err != nil {
+					t.Fatalf("Indexed query failed: %v\n", err)
+				}
+
+				if len(actual) != len(expected) {
+					t.Fatalf("Expected %v neighbors, got %v", len(expected), len(actual))
+				}
+
+				for n := range expected {
+					if math.Abs(actual[n].Distance - expected[n].Distance) > 1e-9 {
+						t.Fatalf("Algorithm %v, query %v: neighbor %v at %v, expected %v",
+							algorithm, q, n, actual[n].Distance, expected[n].Distance)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestKNNFitAlgorithmSelection(t *testing.T) {
+	lowDimensional, labels := randomKNNData(200, 3, 1)
+
+	model, err := InitKNN[float64, uint64](3, AutoAlgorithm)
+	if err != nil {
+		t.Fatalf("InitKNN failed: %v\n", err)
+	}
+
+	err = model.Fit(lowDimensional, labels)
+	if err != nil {
+		t.Fatalf("KNN Fit failed: %v\n", err)
+	}
+
+	if _, ok := model.index.(*kdTree); !ok {
+		t.Errorf("Expected AutoAlgorithm to build a KD-tree for 3 features")
+	}
+
+	highDimensional, labels := randomKNNData(200, 40, 2)
+	err = model.Fit(highDimensional, labels)
+	if err != nil {
+		t.Fatalf("KNN Fit failed: %v\n", err)
+	}
+
+	if model.index != nil {
+		t.Errorf("Expected AutoAlgorithm to fall back to brute force for 40 features")
+	}
+
+	// K larger than the training set returns every point
+	small, smallLabels := randomKNNData(5, 2, 3)
+	tree, _ := InitKNN[float64, uint64](10, KDTreeAlgorithm)
+	tree.Fit(small, smallLabels)
+
+	query, _ := small.GetBatchSlice(0, 1)
+	neighbors, err := FindKNearestLabels(tree, query)
+	if err != nil {
+		t.Fatalf("FindKNearestLabels failed: %v\n", err)
+	}
+
+	if len(neighbors) != 5 || neighbors[0].Distance != 0 {
+		t.Errorf("Unexpected neighbors for K > N: %v", neighbors)
+	}
+
+	_, err = InitKNN[float64, uint64](0, AutoAlgorithm)
+	if err == nil {
+		t.Errorf("Expected an error for K of 0")
+	}
+}
+
+func benchmarkKNNQuery(b *testing.B, algorithm NeighborAlgorithm) {
+	features, labels := randomKNNData(20000, 3, 5)
+	queries, _ := randomKNNData(100, 3, 6)
+
+	model, _ := InitKNN[float64, uint64](5, algorithm)
+	err := model.Fit(features, labels)
+	if err != nil {
+		b.Fatalf("KNN Fit failed: %v\n", err)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		query, _ := queries.GetBatchSlice(uint64(n % 100), 1)
+		_, err := FindKNearestLabels(model, query)
+		if err != nil {
+			b.Fatalf("FindKNearestLabels failed: %v\n", err)
+		}
+	}
+}
+
+func BenchmarkKNNBruteForce(b *testing.B) {
+	benchmarkKNNQuery(b, BruteForceAlgorithm)
+}
+
+func BenchmarkKNNKDTree(b *testing.B) {
+	benchmarkKNNQuery(b, KDTreeAlgorithm)
+}
+
+func BenchmarkKNNBallTree(b *testing.B) {
+	benchmarkKNNQuery(b, BallTreeAlgorithm)
+}
+
+func TestDistanceMetrics(t *testing.T) {
+	a := []float64{1.0, 2.0, 3.0}
+	b := []float64{4.0, 0.0, 3.0}
+
+	cases := []struct {
+		metric		DistanceMetric
+		p		float64
+		expected	float64
+	}{
+		{EuclideanMetric, 0, math.Sqrt(13.0)},
+		{ManhattanMetric, 0, 5.0},
+		{ChebyshevMetric, 0, 3.0},
+		{MinkowskiMetric, 3, math.Cbrt(35.0)},
+		{MinkowskiMetric, 2, math.Sqrt(13.0)},
+		{CosineMetric, 0, 1.0 - 13.0 / (math.Sqrt(14.0) * 5.0)},
+	}
+
+	for _, c := range cases {
+		distance, err := resolveDistance(c.metric, c.p, nil)
+		if err != nil {
+			t.Fatalf("resolveDistance failed for metric %v: %v\n", c.metric, err)
+		}
+
+		actual := distance(a, b)
+		if math.Abs(actual - c.expected) > 1e-12 {
+			t.Errorf("Metric %v (p=%v): got %v, expected %v", c.metric, c.p, actual, c.expected)
+		}
+	}
+
+	_, err := resolveDistance(MinkowskiMetric, 0.5, nil)
+	if err == nil {
+		t.Errorf("Expected an error for Minkowski p < 1")
+	}
+
+	_, err = resolveDistance(CustomMetric, 0, nil)
+	if err == nil {
+		t.Errorf("Expected an error for a custom metric without a function")
+	}
+}
+
+func TestKNNMetricsWithIndexes(t *testing.T) {
+	features, labels := randomKNNData(400, 3, 12)
+	queries, _ := randomKNNData(20, 3, 13)
+
+	metrics := []DistanceMetric{ManhattanMetric, ChebyshevMetric, MinkowskiMetric}
+	weights := []float64{1.0, 0.5, 2.0}
+
+	for _, metric := range metrics {
+		brute, _ := InitKNN[float64, uint64](4, BruteForceAlgorithm)
+		brute.Metric = metric
+		brute.MinkowskiP = 3
+		brute.FeatureWeights = weights
+		brute.Fit(features, labels)
+
+		for _, algorithm := range []NeighborAlgorithm{KDTreeAlgorithm, BallTreeAlgorithm} {
+			indexed, _ := InitKNN[float64, uint64](4, algorithm)
+			indexed.Metric = metric
+			indexed.MinkowskiP = 3
+			indexed.FeatureWeights = weights
+			indexed.LeafSize = 5
+
+			err := indexed.Fit(features, labels)
+			if err != nil {
+				t.Fatalf("Fit failed for metric %v: %v\n", metric, err)
+			}
+
+			for q := uint64(0); q < 20; q++ {
+				query, _ := queries.GetBatchSlice(q, 1)
+				expected, _ := FindKNearestLabels(brute, query)
+				actual, err := FindKNearestLabels(indexed, query)
+				if err != nil {
+					t.Fatalf("Indexed query failed: %v\n", err)
+				}
+
+				for n := range expected {
+					if math.Abs(actual[n].Distance - expected[n].Distance) > 1e-9 {
+						t.Fatalf("Metric %v, algorithm %v: neighbor %v at %v, expected %v",
+							metric, algorithm, n, actual[n].Distance, expected[n].Distance)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestKNNCosineAndCustomMetrics(t *testing.T) {
+	features, _ := InitTensor64(3, 2)
+	features.Data = []float64{10.0, 0.0, 0.0, 1.0, 1.0, 1.0}
+	labels := []string{"east", "north", "diagonal"}
+
+	query, _ := InitTensor64(1, 2)
+	query.Data = []float64{0.1, 0.0}
+
+	cosine, _ := InitKNN[float64, uint64](1, AutoAlgorithm)
+	cosine.Metric = CosineMetric
+	err := cosine.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("KNN Fit failed: %v\n", err)
+	}
+
+	// by direction the query matches east, although it is nearest to north
+	label, err := cosine.Predict(query)
+	if err != nil || label != "east" {
+		t.Errorf("Expected cosine KNN to predict east, got %v (%v)", label, err)
+	}
+
+	tree, _ := InitKNN[float64, uint64](1, KDTreeAlgorithm)
+	tree.Metric = CosineMetric
+	err = tree.Fit(features, labels)
+	if err == nil {
+		t.Errorf("Expected an error building a KD-tree for cosine distance")
+	}
+
+	// a custom metric that only looks at the second feature
+	custom, _ := InitKNN[float64, uint64](1, AutoAlgorithm)
+	custom.Metric = CustomMetric
+	custom.CustomDistance = func(a, b []float64) float64 {
+		return math.Abs(a[1] - b[1])
+	}
+	custom.Fit(features, labels)
+
+	query.Data = []float64{9.0, 0.9}
+	label, _ = custom.Predict(query)
+	if label != "north" && label != "diagonal" {
+		t.Errorf("Expected the custom metric to ignore the first feature, got %v", label)
+	}
+
+	// weighting away the second feature makes east the nearest again
+	weighted, _ := InitKNN[float64, uint64](1, BruteForceAlgorithm)
+	weighted.FeatureWeights = []float64{1.0, 0.0}
+	weighted.Fit(features, labels)
+
+	label, _ = weighted.Predict(query)
+	if label != "east" {
+		t.Errorf("Expected feature weights to change the prediction, got %v", label)
+	}
+}
+
+func TestVoteShares(t *testing.T) {
+	neighbors := []Neighbor[float64] {
+		{Distance: 1.0, Label: "Cat"},
+		{Distance: 2.0, Label: "Dog"},
+		{Distance: 4.0, Label: "Dog"},
+	}
+
+	tol := 1e-9
+
+	uniform, err := VoteShares(neighbors, UniformWeighting, 0)
+	if err != nil {
+		t.Fatalf("VoteShares failed: %v", err)
+	}
+	if math.Abs(uniform["Cat"] - 1.0 / 3.0) > tol || math.Abs(uniform["Dog"] - 2.0 / 3.0) > tol {
+		t.Errorf("Unexpected uniform shares: %v", uniform)
+	}
+
+	// weights 1, 1/2 and 1/4 out of a total of 7/4
+	inverse, _ := VoteShares(neighbors, DistanceWeighting, 0)
+	if math.Abs(inverse["Cat"] - 4.0 / 7.0) > tol || math.Abs(inverse["Dog"] - 3.0 / 7.0) > tol {
+		t.Errorf("Unexpected inverse-distance shares: %v", inverse)
+	}
+
+	kernel, _ := VoteShares(neighbors, KernelWeighting, 1.0)
+	catWeight := math.Exp(-0.5)
+	dogWeight := math.Exp(-2.0) + math.Exp(-8.0)
+	if math.Abs(kernel["Cat"] - catWeight / (catWeight + dogWeight)) > tol {
+		t.Errorf("Unexpected kernel shares: %v", kernel)
+	}
+
+	// an exact match takes the whole vote
+	neighbors[1].Distance = 0.0
+	exact, _ := VoteShares(neighbors, DistanceWeighting, 0)
+	if exact["Dog"] != 1.0 || exact["Cat"] != 0.0 {
+		t.Errorf("Expected an exact match to win every vote, got %v", exact)
+	}
+
+	_, err = VoteShares([]Neighbor[float64]{}, DistanceWeighting, 0)
+	if err == nil {
+		t.Errorf("Expected an error for an empty neighbor list")
+	}
+}
+
+func TestKNNWeightedPredict(t *testing.T) {
+	features, _ := InitTensor64(3, 1)
+	features.Data = []float64{0.0, 4.0, 5.0}
+	labels := []string{"near", "far", "far"}
+
+	query, _ := InitTensor64(1, 1)
+	query.Data = []float64{1.0}
+
+	knn, _ := InitKNN[float64, uint64](3, BruteForceAlgorithm)
+	knn.Fit(features, labels)
+
+	label, _ := knn.Predict(query)
+	if label != "far" {
+		t.Errorf("Expected the uniform vote to pick far, got %v", label)
+	}
+
+	knn.Weighting = DistanceWeighting
+	label, err := knn.Predict(query)
+	if err != nil || label != "near" {
+		t.Errorf("Expected the inverse-distance vote to pick near, got %v (%v)", label, err)
+	}
+
+	shares, err := knn.PredictProba(query)
+	if err != nil {
+		t.Fatalf("PredictProba failed: %v", err)
+	}
+
+	total := 0.0
+	for _, share := range shares {
+		total += share
+	}
+	if math.Abs(total - 1.0) > 1e-9 || shares["near"] <= shares["far"] {
+		t.Errorf("Unexpected vote shares: %v", shares)
+	}
+
+	knn.Weighting = KernelWeighting
+	knn.Bandwidth = 1.0
+	label, _ = knn.Predict(query)
+	if label != "near" {
+		t.Errorf("Expected the kernel vote to pick near, got %v", label)
+	}
+}
+
+func TestKNNRegressor(t *testing.T) {
+	features, _ := InitTensor64(4, 1)
+	features.Data = []float64{0.0, 1.0, 2.0, 10.0}
+
+	targets, _ := InitTensor64(4, 2)
+	targets.Data = []float64{0.0, 0.0, 2.0, 20.0, 4.0, 40.0, 100.0, 1000.0}
+
+	model, err := InitKNNRegressor[float64, uint64](3, KDTreeAlgorithm)
+	if err != nil {
+		t.Fatalf("InitKNNRegressor failed: %v", err)
+	}
+
+	query, _ := InitTensor64(1, 1)
+	query.Data = []float64{1.0}
+
+	_, err = model.Predict(query)
+	if err == nil {
+		t.Errorf("Expected an error predicting before Fit")
+	}
+
+	err = model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("KNNRegressor Fit failed: %v", err)
+	}
+
+	prediction, err := model.Predict(query)
+	if err != nil {
+		t.Fatalf("KNNRegressor Predict failed: %v", err)
+	}
+
+	if prediction.Shape[0] != 1 || prediction.Shape[1] != 2 {
+		t.Fatalf("Expected a [1, 2] prediction, got %v", prediction.Shape)
+	}
+
+	tol := 1e-9
+	if math.Abs(prediction.Data[0] - 2.0) > tol || math.Abs(prediction.Data[1] - 20.0) > tol {
+		t.Errorf("Unexpected uniform prediction: %v", prediction.Data)
+	}
+
+	// the exact match at 1.0 takes all of the weight
+	model.Neighbors.Weighting = DistanceWeighting
+	prediction, _ = model.Predict(query)
+	if math.Abs(prediction.Data[0] - 2.0) > tol || math.Abs(prediction.Data[1] - 20.0) > tol {
+		t.Errorf("Unexpected inverse-distance prediction: %v", prediction.Data)
+	}
+
+	// weights 1/0.5, 1/0.5 and 1/1.5 for targets 2, 4 and 0
+	query.Data = []float64{1.5}
+	prediction, _ = model.Predict(query)
+	expected := (2.0 * 2.0 + 2.0 * 4.0) / (2.0 + 2.0 + 1.0 / 1.5)
+	if math.Abs(prediction.Data[0] - expected) > tol {
+		t.Errorf("Expected %v, got %v", expected, prediction.Data[0])
+	}
+
+	flatTargets, _ := InitTensor[float64, uint64]([]uint64{4})
+	flatTargets.Data = []float64{1.0, 2.0, 3.0, 4.0}
+	model.Neighbors.Weighting = UniformWeighting
+	model.Fit(features, flatTargets)
+
+	prediction, _ = model.Predict(query)
+	if prediction.Shape[1] != 1 || math.Abs(prediction.Data[0] - 2.0) > tol {
+		t.Errorf("Unexpected prediction for 1D targets: %v %v", prediction.Shape, prediction.Data)
+	}
+
+	badTargets, _ := InitTensor64(3, 1)
+	err = model.Fit(features, badTargets)
+	if err == nil {
+		t.Errorf("Expected an error for mismatched targets")
+	}
+}
+
+func TestKNNPredictBatch(t *testing.T) {
+	features, labels := randomKNNData(300, 4, 11)
+	queries, _ := randomKNNData(150, 4, 12)
+
+	for _, algorithm := range []NeighborAlgorithm{BruteForceAlgorithm, KDTreeAlgorithm, BallTreeAlgorithm} {
+		for _, metric := range []DistanceMetric{EuclideanMetric, ManhattanMetric} {
+			model, _ := InitKNN[float64, uint64](1, algorithm)
+			model.Metric = metric
+			model.NumWorkers = 3
+			err := model.Fit(features, labels)
+			if err != nil {
+				t.Fatalf("KNN Fit failed: %v\n", err)
+			}
+
+			predictions, err := model.PredictBatch(queries)
+			if err != nil {
+				t.Fatalf("PredictBatch failed: %v\n", err)
+			}
+
+			if len(predictions) != 150 {
+				t.Fatalf("Expected 150 predictions, got %v", len(predictions))
+			}
+
+			for n := uint64(0); n < 150; n++ {
+				query, _ := queries.GetBatchSlice(n, 1)
+				expected, _ := model.Predict(query)
+				if predictions[n] != expected {
+					t.Errorf("Algorithm %v metric %v query %v: PredictBatch gave %v, Predict gave %v", algorithm, metric, n, predictions[n], expected)
+				}
+			}
+		}
+	}
+
+	// the distance matrix identity matches the direct distances
+	model, _ := InitKNN[float64, uint64](5, BruteForceAlgorithm)
+	model.Fit(features, labels)
+
+	batch, err := model.nearestBatch(queries)
+	if err != nil {
+		t.Fatalf("nearestBatch failed: %v\n", err)
+	}
+
+	query, _ := queries.GetBatchSlice(7, 1)
+	single, _ := model.nearest(query)
+	for n := range single {
+		if math.Abs(batch[7][n].distance - single[n].distance) > 1e-9 {
+			t.Errorf("Unexpected batch distances: got %v, expected %v", batch[7], single)
+		}
+	}
+
+	wrongShape, _ := InitTensor64(2, 3)
+	_, err = model.PredictBatch(wrongShape)
+	if err == nil {
+		t.Errorf("Expected an error for queries with the wrong number of features")
+	}
+
+	unfitted, _ := InitKNN[float64, uint64](1, AutoAlgorithm)
+	_, err = unfitted.PredictBatch(queries)
+	if err == nil {
+		t.Errorf("Expected an error predicting before Fit")
+	}
+}
+
+func TestKNNRegressorPredictBatch(t *testing.T) {
+	features, _ := randomKNNData(200, 3, 13)
+	queries, _ := randomKNNData(70, 3, 14)
+
+	targets, _ := InitTensor64(200, 2)
+	for n := uint64(0); n < 200; n++ {
+		targets.Data[n * 2] = features.Data[n * 3] + features.Data[n * 3 + 1]
+		targets.Data[n * 2 + 1] = features.Data[n * 3 + 2]
+	}
+
+	model, _ := InitKNNRegressor[float64, uint64](4, AutoAlgorithm)
+	model.Neighbors.Weighting = DistanceWeighting
+	err := model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("KNNRegressor Fit failed: %v\n", err)
+	}
+
+	predictions, err := model.PredictBatch(queries)
+	if err != nil {
+		t.Fatalf("KNNRegressor PredictBatch failed: %v\n", err)
+	}
+
+	if predictions.Shape[0] != 70 || predictions.Shape[1] != 2 {
+		t.Fatalf("Expected [70, 2] predictions, got %v", predictions.Shape)
+	}
+
+	for n := uint64(0); n < 70; n++ {
+		query, _ := queries.GetBatchSlice(n, 1)
+		expected, _ := model.Predict(query)
+		for m := uint64(0); m < 2; m++ {
+			if math.Abs(predictions.Data[n * 2 + m] - expected.Data[m]) > 1e-9 {
+				t.Errorf("Query %v: PredictBatch gave %v, Predict gave %v", n, predictions.Data[n * 2: n * 2 + 2], expected.Data)
+			}
+		}
+	}
+}
+
+func benchmarkKNNBatch(b *testing.B, batch bool) {
+	features, labels := randomKNNData(5000, 32, 15)
+	queries, _ := randomKNNData(500, 32, 16)
+
+	model, _ := InitKNN[float64, uint64](5, BruteForceAlgorithm)
+	model.Fit(features, labels)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if batch {
+			model.PredictBatch(queries)
+			continue
+		}
+
+		for q := uint64(0); q < 500; q++ {
+			query, _ := queries.GetBatchSlice(q, 1)
+			model.Predict(query)
+		}
+	}
+}
+
+func BenchmarkKNNPredictLoop(b *testing.B) {
+	benchmarkKNNBatch(b, false)
+}
+
+func BenchmarkKNNPredictBatch(b *testing.B) {
+	benchmarkKNNBatch(b, true)
+}
@@ -0,0 +1,651 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	jacobiMaxSweeps = 100
+	svdMaxIterationsPerValue = 75
+)
+
+func matrixToFloat64[T Numeric, S Index](input *Tensor[T, S]) ([][]float64, error) {
+	if len(input.Shape) != 2 {
+		return nil, errors.New("Matrix routines require a 2D tensor")
+	}
+
+	numRows := input.Shape[0]
+	numCols := input.Shape[1]
+	rowStride := input.Strides[0]
+	colStride := input.Strides[1]
+
+	result := make([][]float64, numRows)
+	for i := S(0); i < numRows; i++ {
+		result[i] = make([]float64, numCols)
+		for j := S(0); j < numCols; j++ {
+			result[i][j] = float64(input.Data[i * rowStride + j * colStride])
+		}
+	}
+
+	return result, nil
+}
+
+func float64ToMatrix[T Numeric, S Index](rows [][]float64, numRows, numCols S) (*Tensor[T, S], error) {
+	result, err := InitTensor[T, S]([]S{numRows, numCols})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for i := S(0); i < numRows; i++ {
+		for j := S(0); j < numCols; j++ {
+			result.Data[i * numCols + j] = T(rows[i][j])
+		}
+	}
+
+	return result, nil
+}
+
+// SymmetricEigen decomposes a symmetric matrix with the cyclic Jacobi method.
+// Eigenvalues are returned in descending order as a 1D tensor, and the
+// matching unit eigenvectors are the columns of the second result.
+func SymmetricEigen[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], *Tensor[T, S], error) {
+	a, err := matrixToFloat64(input)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	n := len(a)
+	if n == 0 || len(a[0]) != n {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("SymmetricEigen requires a square matrix, got shape %v", input.Shape)
+	}
+
+	maxAbs := 0.0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			maxAbs = math.Max(maxAbs, math.Abs(a[i][j]))
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if math.Abs(a[i][j] - a[j][i]) > 1e-9 * math.Max(maxAbs, 1.0) {
+				return &Tensor[T, S]{}, &Tensor[T, S]{}, errors.New("SymmetricEigen requires a symmetric matrix")
+			}
+		}
+	}
+
+	v := make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+		v[i][i] = 1.0
+	}
+
+	converged := false
+
+	for sweep := 0; sweep < jacobiMaxSweeps; sweep++ {
+		offDiagonal := 0.0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				offDiagonal += a[i][j] * a[i][j]
+			}
+		}
+
+		if offDiagonal <= 1e-30 * math.Max(maxAbs * maxAbs, 1e-300) {
+			converged = true
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+
+				theta := (a[q][q] - a[p][p]) / (2.0 * a[p][q])
+				t := 1.0 / (math.Abs(theta) + math.Sqrt(theta * theta + 1.0))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1.0 / math.Sqrt(t * t + 1.0)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					akp := a[k][p]
+					akq := a[k][q]
+					a[k][p] = c * akp - s * akq
+					a[k][q] = s * akp + c * akq
+				}
+
+				for k := 0; k < n; k++ {
+					apk := a[p][k]
+					aqk := a[q][k]
+					a[p][k] = c * apk - s * aqk
+					a[q][k] = s * apk + c * aqk
+				}
+
+				for k := 0; k < n; k++ {
+					vkp := v[k][p]
+					vkq := v[k][q]
+					v[k][p] = c * vkp - s * vkq
+					v[k][q] = s * vkp + c * vkq
+				}
+			}
+		}
+	}
+
+	if !converged {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, fmt.Errorf("SymmetricEigen failed to converge after %v sweeps", jacobiMaxSweeps)
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return a[order[i]][order[i]] > a[order[j]][order[j]]
+	})
+
+	eigenvalues, err := InitTensor[T, S]([]S{S(n)})
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	eigenvectors, err := InitTensor[T, S]([]S{S(n), S(n)})
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	for col, source := range order {
+		eigenvalues.Data[col] = T(a[source][source])
+		for row := 0; row < n; row++ {
+			eigenvectors.Data[row * n + col] = T(v[row][source])
+		}
+	}
+
+	return eigenvalues, eigenvectors, nil
+}
+
+// SVD computes the thin singular value decomposition input = U * diag(Sigma) * V^T
+// using Golub-Kahan bidiagonalization followed by implicit-shift QR sweeps.
+// For an [M, N] input with K = min(M, N), U is [M, K], Sigma is [K] in
+// descending order and V is [N, K].
+func SVD[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], *Tensor[T, S], *Tensor[T, S], error) {
+	a, err := matrixToFloat64(input)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	m := len(a)
+	if m == 0 || len(a[0]) == 0 {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, errors.New("SVD requires a non-empty matrix")
+	}
+	n := len(a[0])
+
+	transposed := false
+	if m < n {
+		transposed = true
+		at := make([][]float64, n)
+		for i := range at {
+			at[i] = make([]float64, m)
+			for j := 0; j < m; j++ {
+				at[i][j] = a[j][i]
+			}
+		}
+		a = at
+		m, n = n, m
+	}
+
+	u, sigma, v, err := golubKahanSVD(a, m, n)
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	if transposed {
+		u, v = v, u
+		m, n = n, m
+	}
+
+	k := min(m, n)
+
+	uTensor, err := float64ToMatrix[T, S](u, S(m), S(k))
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	sigmaTensor, err := InitTensor[T, S]([]S{S(k)})
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+	for i := 0; i < k; i++ {
+		sigmaTensor.Data[i] = T(sigma[i])
+	}
+
+	vTensor, err := float64ToMatrix[T, S](v, S(n), S(k))
+	if err != nil {
+		return &Tensor[T, S]{}, &Tensor[T, S]{}, &Tensor[T, S]{}, err
+	}
+
+	return uTensor, sigmaTensor, vTensor, nil
+}
+
+func golubKahanSVD(a [][]float64, m, n int) ([][]float64, []float64, [][]float64, error) {
+	nu := min(m, n)
+	s := make([]float64, min(m + 1, n))
+	u := make([][]float64, m)
+	for i := range u {
+		u[i] = make([]float64, nu)
+	}
+	v := make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+	}
+	e := make([]float64, n)
+	work := make([]float64, m)
+
+	// Reduce a to bidiagonal form, storing the diagonal in s and the
+	// super-diagonal in e.
+	nct := min(m - 1, n)
+	nrt := max(0, min(n - 2, m))
+	for k := 0; k < max(nct, nrt); k++ {
+		if k < nct {
+			s[k] = 0
+			for i := k; i < m; i++ {
+				s[k] = math.Hypot(s[k], a[i][k])
+			}
+			if s[k] != 0.0 {
+				if a[k][k] < 0.0 {
+					s[k] = -s[k]
+				}
+				for i := k; i < m; i++ {
+					a[i][k] /= s[k]
+				}
+				a[k][k] += 1.0
+			}
+			s[k] = -s[k]
+		}
+
+		for j := k + 1; j < n; j++ {
+			if k < nct && s[k] != 0.0 {
+				t := 0.0
+				for i := k; i < m; i++ {
+					t += a[i][k] * a[i][j]
+				}
+				t = -t / a[k][k]
+				for i := k; i < m; i++ {
+					a[i][j] += t * a[i][k]
+				}
+			}
+			e[j] = a[k][j]
+		}
+
+		if k < nct {
+			for i := k; i < m; i++ {
+				u[i][k] = a[i][k]
+			}
+		}
+
+		if k < nrt {
+			e[k] = 0
+			for i := k + 1; i < n; i++ {
+				e[k] = math.Hypot(e[k], e[i])
+			}
+			if e[k] != 0.0 {
+				if e[k + 1] < 0.0 {
+					e[k] = -e[k]
+				}
+				for i := k + 1; i < n; i++ {
+					e[i] /= e[k]
+				}
+				e[k + 1] += 1.0
+			}
+			e[k] = -e[k]
+
+			if k + 1 < m && e[k] != 0.0 {
+				for i := k + 1; i < m; i++ {
+					work[i] = 0.0
+				}
+				for j := k + 1; j < n; j++ {
+					for i := k + 1; i < m; i++ {
+						work[i] += e[j] * a[i][j]
+					}
+				}
+				for j := k + 1; j < n; j++ {
+					t := -e[j] / e[k + 1]
+					for i := k + 1; i < m; i++ {
+						a[i][j] += t * work[i]
+					}
+				}
+			}
+
+			for i := k + 1; i < n; i++ {
+				v[i][k] = e[i]
+			}
+		}
+	}
+
+	p := min(n, m + 1)
+	if nct < n {
+		s[nct] = a[nct][nct]
+	}
+	if m < p {
+		s[p - 1] = 0.0
+	}
+	if nrt + 1 < p {
+		e[nrt] = a[nrt][p - 1]
+	}
+	e[p - 1] = 0.0
+
+	// Accumulate the left transformations.
+	for j := nct; j < nu; j++ {
+		for i := 0; i < m; i++ {
+			u[i][j] = 0.0
+		}
+		u[j][j] = 1.0
+	}
+	for k := nct - 1; k >= 0; k-- {
+		if s[k] != 0.0 {
+			for j := k + 1; j < nu; j++ {
+				t := 0.0
+				for i := k; i < m; i++ {
+					t += u[i][k] * u[i][j]
+				}
+				t = -t / u[k][k]
+				for i := k; i < m; i++ {
+					u[i][j] += t * u[i][k]
+				}
+			}
+			for i := k; i < m; i++ {
+				u[i][k] = -u[i][k]
+			}
+			u[k][k] = 1.0 + u[k][k]
+			for i := 0; i < k - 1; i++ {
+				u[i][k] = 0.0
+			}
+		} else {
+			for i := 0; i < m; i++ {
+				u[i][k] = 0.0
+			}
+			u[k][k] = 1.0
+		}
+	}
+
+	// Accumulate the right transformations.
+	for k := n - 1; k >= 0; k-- {
+		if k < nrt && e[k] != 0.0 {
+			for j := k + 1; j < nu; j++ {
+				t := 0.0
+				for i := k + 1; i < n; i++ {
+					t += v[i][k] * v[i][j]
+				}
+				t = -t / v[k + 1][k]
+				for i := k + 1; i < n; i++ {
+					v[i][j] += t * v[i][k]
+				}
+			}
+		}
+		for i := 0; i < n; i++ {
+			v[i][k] = 0.0
+		}
+		v[k][k] = 1.0
+	}
+
+	// Diagonalize the bidiagonal form with implicit-shift QR sweeps.
+	pp := p - 1
+	iterations := 0
+	maxIterations := svdMaxIterationsPerValue * max(n, 1)
+	eps := math.Pow(2.0, -52.0)
+	tiny := math.Pow(2.0, -966.0)
+
+	for p > 0 {
+		if iterations > maxIterations {
+			return nil, nil, nil, fmt.Errorf("SVD failed to converge after %v iterations", maxIterations)
+		}
+
+		var k, kase int
+
+		for k = p - 2; k >= 0; k-- {
+			if math.Abs(e[k]) <= tiny + eps * (math.Abs(s[k]) + math.Abs(s[k + 1])) {
+				e[k] = 0.0
+				break
+			}
+		}
+
+		if k == p - 2 {
+			kase = 4
+		} else {
+			var ks int
+			for ks = p - 1; ks > k; ks-- {
+				t := 0.0
+				if ks != p {
+					t += math.Abs(e[ks])
+				}
+				if ks != k + 1 {
+					t += math.Abs(e[ks - 1])
+				}
+				if math.Abs(s[ks]) <= tiny + eps * t {
+					s[ks] = 0.0
+					break
+				}
+			}
+
+			if ks == k {
+				kase = 3
+			} else if ks == p - 1 {
+				kase = 1
+			} else {
+				kase = 2
+				k = ks
+			}
+		}
+		k++
+
+		switch kase {
+		case 1:
+			// Deflate negligible s[p-1].
+			f := e[p - 2]
+			e[p - 2] = 0.0
+			for j := p - 2; j >= k; j-- {
+				t := math.Hypot(s[j], f)
+				cs := s[j] / t
+				sn := f / t
+				s[j] = t
+				if j != k {
+					f = -sn * e[j - 1]
+					e[j - 1] = cs * e[j - 1]
+				}
+				for i := 0; i < n; i++ {
+					t = cs * v[i][j] + sn * v[i][p - 1]
+					v[i][p - 1] = -sn * v[i][j] + cs * v[i][p - 1]
+					v[i][j] = t
+				}
+			}
+
+		case 2:
+			// Split at negligible s[k].
+			f := e[k - 1]
+			e[k - 1] = 0.0
+			for j := k; j < p; j++ {
+				t := math.Hypot(s[j], f)
+				cs := s[j] / t
+				sn := f / t
+				s[j] = t
+				f = -sn * e[j]
+				e[j] = cs * e[j]
+				for i := 0; i < m; i++ {
+					t = cs * u[i][j] + sn * u[i][k - 1]
+					u[i][k - 1] = -sn * u[i][j] + cs * u[i][k - 1]
+					u[i][j] = t
+				}
+			}
+
+		case 3:
+			// Perform one QR step.
+			scale := math.Max(math.Max(math.Max(math.Max(
+				math.Abs(s[p - 1]), math.Abs(s[p - 2])), math.Abs(e[p - 2])),
+				math.Abs(s[k])), math.Abs(e[k]))
+			sp := s[p - 1] / scale
+			spm1 := s[p - 2] / scale
+			epm1 := e[p - 2] / scale
+			sk := s[k] / scale
+			ek := e[k] / scale
+			b := ((spm1 + sp) * (spm1 - sp) + epm1 * epm1) / 2.0
+			c := (sp * epm1) * (sp * epm1)
+			shift := 0.0
+			if b != 0.0 || c != 0.0 {
+				shift = math.Sqrt(b * b + c)
+				if b < 0.0 {
+					shift = -shift
+				}
+				shift = c / (b + shift)
+			}
+			f := (sk + sp) * (sk - sp) + shift
+			g := sk * ek
+
+			for j := k; j < p - 1; j++ {
+				t := math.Hypot(f, g)
+				cs := f / t
+				sn := g / t
+				if j != k {
+					e[j - 1] = t
+				}
+				f = cs * s[j] + sn * e[j]
+				e[j] = cs * e[j] - sn * s[j]
+				g = sn * s[j + 1]
+				s[j + 1] = cs * s[j + 1]
+				for i := 0; i < n; i++ {
+					t = cs * v[i][j] + sn * v[i][j + 1]
+					v[i][j + 1] = -sn * v[i][j] + cs * v[i][j + 1]
+					v[i][j] = t
+				}
+
+				t = math.Hypot(f, g)
+				cs = f / t
+				sn = g / t
+				s[j] = t
+				f = cs * e[j] + sn * s[j + 1]
+				s[j + 1] = -sn * e[j] + cs * s[j + 1]
+				g = sn * e[j + 1]
+				e[j + 1] = cs * e[j + 1]
+				if j < m - 1 {
+					for i := 0; i < m; i++ {
+						t = cs * u[i][j] + sn * u[i][j + 1]
+						u[i][j + 1] = -sn * u[i][j] + cs * u[i][j + 1]
+						u[i][j] = t
+					}
+				}
+			}
+			e[p - 2] = f
+			iterations++
+
+		case 4:
+			// Convergence: make the singular value positive and order it.
+			if s[k] <= 0.0 {
+				if s[k] < 0.0 {
+					s[k] = -s[k]
+				} else {
+					s[k] = 0.0
+				}
+				for i := 0; i <= pp; i++ {
+					v[i][k] = -v[i][k]
+				}
+			}
+
+			for k < pp {
+				if s[k] >= s[k + 1] {
+					break
+				}
+				s[k], s[k + 1] = s[k + 1], s[k]
+				if k < n - 1 {
+					for i := 0; i < n; i++ {
+						v[i][k], v[i][k + 1] = v[i][k + 1], v[i][k]
+					}
+				}
+				if k < m - 1 {
+					for i := 0; i < m; i++ {
+						u[i][k], u[i][k + 1] = u[i][k + 1], u[i][k]
+					}
+				}
+				k++
+			}
+			iterations = 0
+			p--
+		}
+	}
+
+	return u, s[:nu], v, nil
+}
+
+func singularValueTolerance(sigma []float64, m, n int) float64 {
+	largest := 0.0
+	for _, val := range sigma {
+		largest = math.Max(largest, val)
+	}
+
+	return float64(max(m, n)) * largest * math.Pow(2.0, -52.0)
+}
+
+// PInv computes the Moore-Penrose pseudo-inverse of a 2D tensor from its SVD,
+// discarding singular values below max(M, N) * max(Sigma) * machine epsilon.
+func PInv[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	u, sigma, v, err := SVD(input)
+	if err != nil {
+		return &Tensor[T, S]{}, fmt.Errorf("SVD failed during PInv: %v", err)
+	}
+
+	m := u.Shape[0]
+	n := v.Shape[0]
+	k := sigma.Shape[0]
+
+	singularValues := make([]float64, k)
+	for i := range singularValues {
+		singularValues[i] = float64(sigma.Data[i])
+	}
+	tol := singularValueTolerance(singularValues, int(m), int(n))
+
+	result, err := InitTensor[T, S]([]S{n, m})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for i := S(0); i < n; i++ {
+		for j := S(0); j < m; j++ {
+			sum := 0.0
+			for c := S(0); c < k; c++ {
+				if singularValues[c] <= tol {
+					continue
+				}
+				sum += float64(v.Data[i * k + c]) * float64(u.Data[j * k + c]) / singularValues[c]
+			}
+			result.Data[i * m + j] = T(sum)
+		}
+	}
+
+	return result, nil
+}
+
+// MatrixRank counts the singular values of a 2D tensor above the same
+// tolerance used by PInv.
+func MatrixRank[T Numeric, S Index](input *Tensor[T, S]) (S, error) {
+	_, sigma, _, err := SVD(input)
+	if err != nil {
+		return S(0), fmt.Errorf("SVD failed during MatrixRank: %v", err)
+	}
+
+	singularValues := make([]float64, len(sigma.Data))
+	for i := range singularValues {
+		singularValues[i] = float64(sigma.Data[i])
+	}
+	tol := singularValueTolerance(singularValues, int(input.Shape[0]), int(input.Shape[1]))
+
+	rank := S(0)
+	for _, val := range singularValues {
+		if val > tol {
+			rank++
+		}
+	}
+
+	return rank, nil
+}
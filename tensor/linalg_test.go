@@ -0,0 +1,242 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func reconstructFromSVD(u, sigma, v *Tensor[float64, uint64]) *Tensor[float64, uint64] {
+	m := u.Shape[0]
+	n := v.Shape[0]
+	k := sigma.Shape[0]
+
+	result, _ := InitTensor64(m, n)
+	for i := uint64(0); i < m; i++ {
+		for j := uint64(0); j < n; j++ {
+			sum := 0.0
+			for c := uint64(0); c < k; c++ {
+				sum += u.Data[i * k + c] * sigma.Data[c] * v.Data[j * k + c]
+			}
+			result.Data[i * n + j] = sum
+		}
+	}
+
+	return result
+}
+
+func TestSymmetricEigen(t *testing.T) {
+	tol := 1e-9
+
+	input, _ := InitTensor64(2, 2)
+	input.Data = []float64{2.0, 1.0, 1.0, 2.0}
+
+	values, vectors, err := SymmetricEigen(input)
+	if err != nil {
+		t.Fatalf("SymmetricEigen failed: %v\n", err)
+	}
+
+	expectedValues := []float64{3.0, 1.0}
+	for n := range expectedValues {
+		if math.Abs(values.Data[n] - expectedValues[n]) > tol {
+			t.Errorf("Unexpected eigenvalues: got %v, expected %v", values.Data, expectedValues)
+		}
+	}
+
+	// eigenvectors are only defined up to sign
+	invSqrt2 := 1.0 / math.Sqrt(2.0)
+	if math.Abs(math.Abs(vectors.Data[0]) - invSqrt2) > tol || math.Abs(vectors.Data[0] - vectors.Data[2]) > tol {
+		t.Errorf("Unexpected leading eigenvector: %v", vectors.Data)
+	}
+
+	if math.Abs(math.Abs(vectors.Data[1]) - invSqrt2) > tol || math.Abs(vectors.Data[1] + vectors.Data[3]) > tol {
+		t.Errorf("Unexpected trailing eigenvector: %v", vectors.Data)
+	}
+}
+
+func TestSymmetricEigenReconstruction(t *testing.T) {
+	tol := 1e-8
+
+	input, _ := InitTensor64(3, 3)
+	input.Data = []float64{4.0, 1.0, -2.0, 1.0, 2.0, 0.0, -2.0, 0.0, 3.0}
+
+	values, vectors, err := SymmetricEigen(input)
+	if err != nil {
+		t.Fatalf("SymmetricEigen failed: %v\n", err)
+	}
+
+	for col := uint64(0); col < 3; col++ {
+		for row := uint64(0); row < 3; row++ {
+			av := 0.0
+			for k := uint64(0); k < 3; k++ {
+				av += input.Data[row * 3 + k] * vectors.Data[k * 3 + col]
+			}
+
+			lv := values.Data[col] * vectors.Data[row * 3 + col]
+			if math.Abs(av - lv) > tol {
+				t.Errorf("A*v != lambda*v for eigenpair %v: %v != %v", col, av, lv)
+			}
+		}
+	}
+
+	for n := 1; n < len(values.Data); n++ {
+		if values.Data[n] > values.Data[n - 1] {
+			t.Errorf("Eigenvalues not in descending order: %v", values.Data)
+		}
+	}
+}
+
+func TestSymmetricEigenRejectsAsymmetric(t *testing.T) {
+	input, _ := InitTensor64(2, 2)
+	input.Data = []float64{1.0, 2.0, 3.0, 4.0}
+
+	_, _, err := SymmetricEigen(input)
+	if err == nil {
+		t.Errorf("Asymmetric matrix not rejected by SymmetricEigen")
+	}
+}
+
+func TestSVDKnownDecomposition(t *testing.T) {
+	tol := 1e-9
+
+	input, _ := InitTensor64(2, 3)
+	input.Data = []float64{3.0, 2.0, 2.0, 2.0, 3.0, -2.0}
+
+	u, sigma, v, err := SVD(input)
+	if err != nil {
+		t.Fatalf("SVD failed: %v\n", err)
+	}
+
+	if u.Shape[0] != 2 || u.Shape[1] != 2 || v.Shape[0] != 3 || v.Shape[1] != 2 {
+		t.Errorf("Unexpected shapes from thin SVD: U %v, V %v", u.Shape, v.Shape)
+	}
+
+	expectedSigma := []float64{5.0, 3.0}
+	for n := range expectedSigma {
+		if math.Abs(sigma.Data[n] - expectedSigma[n]) > tol {
+			t.Errorf("Unexpected singular values: got %v, expected %v", sigma.Data, expectedSigma)
+		}
+	}
+
+	reconstructed := reconstructFromSVD(u, sigma, v)
+	for n := range input.Data {
+		if math.Abs(reconstructed.Data[n] - input.Data[n]) > tol {
+			t.Errorf("SVD does not reconstruct input: got %v, expected %v", reconstructed.Data, input.Data)
+			break
+		}
+	}
+}
+
+func TestSVDTallMatrix(t *testing.T) {
+	tol := 1e-9
+
+	input, _ := InitRandomTensor64(5.0, 6, 4)
+
+	u, sigma, v, err := SVD(input)
+	if err != nil {
+		t.Fatalf("SVD failed on tall matrix: %v\n", err)
+	}
+
+	reconstructed := reconstructFromSVD(u, sigma, v)
+	for n := range input.Data {
+		if math.Abs(reconstructed.Data[n] - input.Data[n]) > tol {
+			t.Errorf("SVD does not reconstruct tall input")
+			break
+		}
+	}
+
+	for i := uint64(0); i < 4; i++ {
+		for j := uint64(0); j < 4; j++ {
+			dot := 0.0
+			for r := uint64(0); r < 6; r++ {
+				dot += u.Data[r * 4 + i] * u.Data[r * 4 + j]
+			}
+
+			expected := 0.0
+			if i == j {
+				expected = 1.0
+			}
+
+			if math.Abs(dot - expected) > tol {
+				t.Errorf("Columns of U are not orthonormal: U_%v . U_%v = %v", i, j, dot)
+			}
+		}
+	}
+}
+
+func TestPInvInvertible(t *testing.T) {
+	tol := 1e-9
+
+	input, _ := InitTensor64(2, 2)
+	input.Data = []float64{4.0, 7.0, 2.0, 6.0}
+
+	inverse, err := PInv(input)
+	if err != nil {
+		t.Fatalf("PInv failed: %v\n", err)
+	}
+
+	expected := []float64{0.6, -0.7, -0.2, 0.4}
+	for n := range expected {
+		if math.Abs(inverse.Data[n] - expected[n]) > tol {
+			t.Errorf("Unexpected pseudo-inverse: got %v, expected %v", inverse.Data, expected)
+			break
+		}
+	}
+}
+
+func TestPInvRankDeficient(t *testing.T) {
+	tol := 1e-9
+
+	input, _ := InitTensor64(3, 2)
+	input.Data = []float64{1.0, 2.0, 2.0, 4.0, 3.0, 6.0}
+
+	pinv, err := PInv(input)
+	if err != nil {
+		t.Fatalf("PInv failed on rank deficient matrix: %v\n", err)
+	}
+
+	if pinv.Shape[0] != 2 || pinv.Shape[1] != 3 {
+		t.Errorf("Unexpected pseudo-inverse shape: %v", pinv.Shape)
+	}
+
+	// A * A+ * A == A
+	aPinv, _ := input.Dot(pinv)
+	roundTrip, _ := aPinv.Dot(input)
+	for n := range input.Data {
+		if math.Abs(roundTrip.Data[n] - input.Data[n]) > tol {
+			t.Errorf("A * PInv(A) * A != A: got %v", roundTrip.Data)
+			break
+		}
+	}
+}
+
+func TestMatrixRank(t *testing.T) {
+	full, _ := InitTensor64(2, 2)
+	full.Data = []float64{4.0, 7.0, 2.0, 6.0}
+
+	rank, err := MatrixRank(full)
+	if err != nil {
+		t.Errorf("MatrixRank failed: %v\n", err)
+	}
+
+	if rank != 2 {
+		t.Errorf("Unexpected rank for full rank matrix: %v", rank)
+	}
+
+	deficient, _ := InitTensor64(3, 3)
+	deficient.Data = []float64{1.0, 2.0, 3.0, 2.0, 4.0, 6.0, 1.0, 0.0, 1.0}
+
+	rank, err = MatrixRank(deficient)
+	if err != nil {
+		t.Errorf("MatrixRank failed: %v\n", err)
+	}
+
+	if rank != 2 {
+		t.Errorf("Unexpected rank for rank deficient matrix: %v", rank)
+	}
+
+	zeros, _ := InitTensor64(2, 3)
+	rank, _ = MatrixRank(zeros)
+	if rank != 0 {
+		t.Errorf("Unexpected rank for zero matrix: %v", rank)
+	}
+}
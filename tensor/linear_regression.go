@@ -19,6 +19,7 @@ type LinearRegressionModel[T Numeric, S Index] struct {
 	MomentumRate	T
 	ClipThreshold	T
 	Velocity	*Tensor[T, S]
+	Regularization	Regularization[T]
 }
 
 func InitLinearRegressionModel[T Numeric, S Index](
@@ -30,16 +31,12 @@ func InitLinearRegressionModel[T Numeric, S Index](
 
 	weightShape := []S{numFeatures, 1}
 
-	weights, err := InitTensor[T, S](weightShape)
+	// tiny starting weights keep the first gradient steps from overshooting
+	weights, err := InitUniformTensor[T, S](weightShape, -1e-6, 0.25e-6, nil)
 	if err != nil {
 		return &LinearRegressionModel[T, S]{}, err
 	}
 
-	for n := range weights.Data {
-		randomVal := rand.Float64() * 1.25 - 1.0
-		weights.Data[n] = T(randomVal * 0.000001)
-	}
-
 	velocity, err := InitTensor[T, S](weightShape)
 	if err != nil {
 		return &LinearRegressionModel[T, S]{}, err
@@ -58,43 +55,61 @@ func InitLinearRegressionModel[T Numeric, S Index](
 }
 
 func (lrm *LinearRegressionModel[T, S]) Fit(X *Tensor[T, S], Y *Tensor[T, S]) error {
+	err := lrm.Regularization.Validate()
+	if err != nil {
+		return err
+	}
+
 	X_T, err := X.Transpose()
 	if err != nil {
 		return err
 	}
 
+	// every iteration reuses these buffers, so the loop itself does not
+	// allocate
+	predictions, err := InitTensor[T, S]([]S{X.Shape[0], lrm.Weights.Shape[1]})
+	if err != nil {
+		return err
+	}
+
+	gradient, err := InitTensor[T, S](lrm.Weights.Shape)
+	if err != nil {
+		return err
+	}
+
+	if lrm.Velocity == nil {
+		lrm.Velocity, err = InitTensor[T, S](lrm.Weights.Shape)
+		if err != nil {
+			return err
+		}
+	}
+
 	for n := S(0); n < lrm.MaxIterations; n++ {
 		if !lrm.Weights.Valid() {
 			return errors.New(fmt.Sprintf("NaN or infinity introduced after %v iterations", n))
 		}
 
-
-		predictions, err := X.Dot(lrm.Weights)
+		err = DotInto(predictions, X, lrm.Weights)
 		if err != nil {
 			return err
 		}
 
-		/*if !predictions.Valid() {
-			return errors.New(fmt.Sprintf("NaN introduced to predictions after %v iterations", n))
-		}*/
-
-		errorVector, err := predictions.Subtract(Y)
+		// predictions now holds the error vector
+		err = predictions.SubtractInPlace(Y)
 		if err != nil {
 			return err
 		}
 
-		/*if !errorVector.Valid() {
-			return errors.New(fmt.Sprintf("NaN introduced to errorVector after %v iterations", n))
-		}*/
+		err = DotInto(gradient, X_T, predictions)
+		if err != nil {
+			return err
+		}
 
-		gradient, err := X_T.Dot(errorVector)
+		err = AddPenaltyGradient(lrm.Regularization, lrm.Weights, gradient, true)
 		if err != nil {
 			return err
 		}
 
-		/*if !gradient.Valid() {
-			return errors.New(fmt.Sprintf("NaN introduced to gradient after %v iterations", n))
-		}*/
 		threshold := float64(lrm.ClipThreshold)
 		if threshold > 0 {
 			gradientNorm, err := gradient.Norm()
@@ -103,42 +118,129 @@ func (lrm *LinearRegressionModel[T, S]) Fit(X *Tensor[T, S], Y *Tensor[T, S]) er
 			}
 
 			if math.Abs(float64(gradientNorm)) > threshold {
-				scalingFactor := T(threshold / float64(gradientNorm))
-
-				gradient, err = gradient.MulScalar(scalingFactor)
+				err = gradient.ScaleInPlace(T(threshold / float64(gradientNorm)))
 				if err != nil {
 					return err
 				}
 			}
 		}
 
-		velocityOldScaled, err := lrm.Velocity.MulScalar(lrm.MomentumRate)
+		// velocity = momentum * velocity + learningRate * gradient
+		err = lrm.Velocity.ScaleInPlace(lrm.MomentumRate)
 		if err != nil {
 			return err
 		}
 
-		gradientScaled, err := gradient.MulScalar(lrm.LearningRate)
+		err = lrm.Velocity.AxpyInPlace(lrm.LearningRate, gradient)
 		if err != nil {
 			return err
 		}
 
-		/*if !gradientScaled.Valid() {
-			return errors.New(fmt.Sprintf("NaN introduced to gradientScaled after %v iterations", n))
-		}*/
-
-		velocityNew, err := velocityOldScaled.Add(gradientScaled)
+		err = lrm.Weights.SubtractInPlace(lrm.Velocity)
 		if err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
 
-		lrm.Velocity = velocityNew
+const coordinateDescentTolerance = 1e-10
 
-		newWeights, err := lrm.Weights.Subtract(velocityNew)
-		if err != nil {
-			return err
+// FitCoordinateDescent minimizes the same penalized least squares cost as Fit,
+// 0.5 * ||X * w - Y||^2 plus the Regularization penalty, one weight at a time.
+// The soft-thresholding update drives weights exactly to zero under an L1 or
+// ElasticNet penalty. X must already contain the AugmentBias column, whose
+// weight is never penalized. MaxIterations bounds the number of full sweeps.
+func (lrm *LinearRegressionModel[T, S]) FitCoordinateDescent(X *Tensor[T, S], Y *Tensor[T, S]) error {
+	err := lrm.Regularization.Validate()
+	if err != nil {
+		return err
+	}
+
+	if len(X.Shape) != 2 {
+		return errors.New("FitCoordinateDescent requires a 2D feature tensor")
+	}
+
+	numSamples := X.Shape[0]
+	numFeatures := X.Shape[1]
+
+	if numFeatures != lrm.Weights.Shape[0] {
+		return fmt.Errorf("Dimensions do not match: %v != %v", numFeatures, lrm.Weights.Shape[0])
+	}
+
+	if S(len(Y.Data)) != numSamples {
+		return fmt.Errorf("Expecting %v targets, got %v", numSamples, len(Y.Data))
+	}
+
+	l1, l2 := lrm.Regularization.coefficients()
+
+	rowStride := X.Strides[0]
+	colStride := X.Strides[1]
+
+	weights := make([]float64, numFeatures)
+	for m := S(0); m < numFeatures; m++ {
+		weights[m] = float64(lrm.Weights.Data[m])
+	}
+
+	columnNorms := make([]float64, numFeatures)
+	residuals := make([]float64, numSamples)
+
+	for n := S(0); n < numSamples; n++ {
+		prediction := 0.0
+		for m := S(0); m < numFeatures; m++ {
+			val := float64(X.Data[n * rowStride + m * colStride])
+			prediction += val * weights[m]
+			columnNorms[m] += val * val
 		}
+		residuals[n] = float64(Y.Data[n * Y.Strides[0]]) - prediction
+	}
+
+	for sweep := S(0); sweep < lrm.MaxIterations; sweep++ {
+		maxChange := 0.0
+		maxWeight := 0.0
+
+		for m := S(0); m < numFeatures; m++ {
+			if columnNorms[m] == 0 {
+				continue
+			}
+
+			rho := 0.0
+			for n := S(0); n < numSamples; n++ {
+				rho += float64(X.Data[n * rowStride + m * colStride]) * residuals[n]
+			}
+			rho += weights[m] * columnNorms[m]
+
+			var updated float64
+			if m == 0 {
+				updated = rho / columnNorms[m]
+			} else {
+				updated = softThreshold(rho, l1) / (columnNorms[m] + l2)
+			}
+
+			delta := updated - weights[m]
+			if delta != 0 {
+				for n := S(0); n < numSamples; n++ {
+					residuals[n] -= delta * float64(X.Data[n * rowStride + m * colStride])
+				}
+				weights[m] = updated
+			}
+
+			maxChange = math.Max(maxChange, math.Abs(delta))
+			maxWeight = math.Max(maxWeight, math.Abs(updated))
+		}
+
+		if maxChange <= coordinateDescentTolerance * math.Max(maxWeight, 1.0) {
+			break
+		}
+	}
+
+	for m := S(0); m < numFeatures; m++ {
+		lrm.Weights.Data[m] = T(weights[m])
+	}
 
-		lrm.Weights = newWeights
+	if !lrm.Weights.Valid() {
+		return errors.New("NaN or infinity introduced during coordinate descent")
 	}
 
 	return nil
@@ -185,3 +185,15 @@ func TestRMSE(t *testing.T) {
 		t.Errorf("Diff of expected versus actual RMSE to large: %v > %v", diff, epsilon)
 	}
 }
+
+func BenchmarkLinearRegressionFit(b *testing.B) {
+	xBase, _ := InitRandomTensor([]uint{1000, 2}, 10.0)
+	y, _ := InitTargetTensor[float64, uint](xBase, []float64{10.0, 5.0, -2.0})
+	xAug, _ := xBase.AugmentBias()
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		lrm, _ := InitLinearRegressionModel[float64, uint](xAug.Shape[1], 0.00001, 0.9, 5.0, uint(100))
+		lrm.Fit(xAug, y)
+	}
+}
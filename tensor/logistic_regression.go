@@ -13,6 +13,7 @@ type LogisticRegressionModel[T Numeric, S Index] struct {
 	BatchSize	S
 	Scaler		*StandardScaler[T, S]
 	CostHistory 	[]T
+	Regularization	Regularization[T]
 }
 
 func InitLogisticRegression[T Numeric, S Index](numFeatures S,
@@ -41,9 +42,14 @@ func InitLogisticRegression[T Numeric, S Index](numFeatures S,
 }
 
 func (lrm *LogisticRegressionModel[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	err := lrm.Regularization.Validate()
+	if err != nil {
+		return err
+	}
+
 	scaler := &StandardScaler[T, S]{}
 
-	err := scaler.FitStatistics(features)
+	err = scaler.FitStatistics(features)
 	if err != nil {
 		return err
 	}
@@ -66,12 +72,31 @@ func (lrm *LogisticRegressionModel[T, S]) Fit(features *Tensor[T, S], targets *T
 	//scaleStride := scaledFeatures.Strides[0]
 	//targetStride := targets.Strides[0]
 
+	// ShuffleTensors swaps in new Data slices, so shuffling a shallow copy
+	// keeps the caller's targets in their original order
+	shuffledTargets := &Tensor[T, S] {
+		Shape:		targets.Shape,
+		Strides:	targets.Strides,
+		Data:		targets.Data,
+	}
+
+	// the batches reuse these buffers instead of allocating every step
+	probabilities, err := InitTensor[T, S]([]S{min(lrm.BatchSize, numSamples), 1})
+	if err != nil {
+		return err
+	}
+
+	gradient, err := InitTensor[T, S](lrm.Weights.Shape)
+	if err != nil {
+		return err
+	}
+
 	for n := S(0); n < lrm.NumIterations; n++ {
 		if !lrm.Weights.Valid() {
 			return fmt.Errorf("NaN or Inf found in Logistic Regression at iteration %v\n", n)
 		}
 
-		err = ShuffleTensors(scaledFeatures, targets)
+		err = ShuffleTensors(scaledFeatures, shuffledTargets)
 		if err != nil {
 			return err
 		}
@@ -86,28 +111,38 @@ func (lrm *LogisticRegressionModel[T, S]) Fit(features *Tensor[T, S], targets *T
 				continue
 			}
 
-			targetBatch, err := targets.GetBatchSlice(startRow, batchSampleCount)
+			targetBatch, err := shuffledTargets.GetBatchSlice(startRow, batchSampleCount)
 			if err != nil {
 				continue
 			}
 
 
-			linearOutput, err := featureBatch.Dot(lrm.Weights)
+			// the last batch may be short, so use the leading rows of the
+			// shared buffer
+			errorTerm := probabilities
+			if batchSampleCount < probabilities.Shape[0] {
+				errorTerm, err = probabilities.GetBatchSlice(0, batchSampleCount)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = DotInto(errorTerm, featureBatch, lrm.Weights)
 			if err != nil {
 				return err
 			}
 
-			linearOutput, err = linearOutput.AddScalar(lrm.Bias)
+			err = errorTerm.AddScalarInPlace(lrm.Bias)
 			if err != nil {
 				return err
 			}
 
-			predictedProbabilities, err := Sigmoid(linearOutput)
+			err = SigmoidInto(errorTerm, errorTerm)
 			if err != nil {
 				return err
 			}
 
-			errorTerm, err := predictedProbabilities.Subtract(targetBatch)
+			err = errorTerm.SubtractInPlace(targetBatch)
 			if err != nil {
 				return err
 			}
@@ -117,12 +152,17 @@ func (lrm *LogisticRegressionModel[T, S]) Fit(features *Tensor[T, S], targets *T
 				return err
 			}
 
-			preGradientWeights, err := transposedFeatures.Dot(errorTerm)
+			err = DotInto(gradient, transposedFeatures, errorTerm)
+			if err != nil {
+				return err
+			}
+
+			err = gradient.ScaleInPlace(T(1.0) / T(batchSampleCount))
 			if err != nil {
 				return err
 			}
 
-			gradientWeights, err := preGradientWeights.MulScalar(T(1.0) / T(batchSampleCount))
+			err = AddPenaltyGradient(lrm.Regularization, lrm.Weights, gradient, false)
 			if err != nil {
 				return err
 			}
@@ -133,21 +173,33 @@ func (lrm *LogisticRegressionModel[T, S]) Fit(features *Tensor[T, S], targets *T
 			}
 			gradientBias := errSum / T(batchSampleCount)
 
-			scaledGradient, err := gradientWeights.MulScalar(lrm.LearningRate)
+			err = gradient.ScaleInPlace(lrm.LearningRate)
+			if err != nil {
+				return err
+			}
 
-			lrm.Weights, err = lrm.Weights.Subtract(scaledGradient)
+			err = lrm.Weights.SubtractInPlace(gradient)
 			if err != nil {
 				return err
 			}
 			lrm.Bias = lrm.Bias - lrm.LearningRate * gradientBias
 		}
-		fullPrediction, _ := lrm.Predict(scaledFeatures)
+		fullLogits, err := lrm.logits(scaledFeatures)
+		if err != nil {
+			return err
+		}
 
-		cost, err := CalculateCost(fullPrediction, targets)
+		cost, err := BinaryCrossEntropyWithLogits(fullLogits, shuffledTargets)
 		if err != nil {
 			return err
 		}
 
+		penaltyCost, err := PenaltyCost(lrm.Regularization, lrm.Weights, false)
+		if err != nil {
+			return err
+		}
+		cost += penaltyCost
+
 		lrm.CostHistory = append(lrm.CostHistory, cost)
 	}
 
@@ -155,20 +207,24 @@ func (lrm *LogisticRegressionModel[T, S]) Fit(features *Tensor[T, S], targets *T
 }
 
 func (lrm *LogisticRegressionModel[T, S]) Predict(input *Tensor[T, S]) (*Tensor[T, S], error) {
-	linearOutput, err := input.Dot(lrm.Weights)
+	linearOutput, err := lrm.logits(input)
 	if err != nil {
 		return &Tensor[T, S]{}, err
 	}
 
-	linearOutput, err = linearOutput.AddScalar(lrm.Bias)
+	predicted, err := Sigmoid(linearOutput)
 	if err != nil {
 		return &Tensor[T, S]{}, err
 	}
 
-	predicted, err := Sigmoid(linearOutput)
+	return predicted, err
+}
+
+func (lrm *LogisticRegressionModel[T, S]) logits(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	linearOutput, err := input.Dot(lrm.Weights)
 	if err != nil {
 		return &Tensor[T, S]{}, err
 	}
 
-	return predicted, err
+	return linearOutput.AddScalar(lrm.Bias)
 }
@@ -129,3 +129,59 @@ func TestPredictLogisticRegression(t *testing.T) {
 	}
 
 }
+
+func TestFitLogisticRegressionKeepsTargetOrder(t *testing.T) {
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{1.0, 1.0, -1.0, -1.0, 1.0, -1.0, -1.0, 1.0}
+
+	targets, _ := InitTensor64(4, 1)
+	targets.Data = []float64{1.0, 0.0, 0.0, 0.0}
+
+	model, _ := InitLogisticRegression[float64, uint64](2, 0.0, 0.1, 50)
+	err := model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("Logistic Fit failed: %v\n", err)
+	}
+
+	expected := []float64{1.0, 0.0, 0.0, 0.0}
+	for n := range expected {
+		if targets.Data[n] != expected[n] {
+			t.Errorf("Fit reordered the caller's targets: %v", targets.Data)
+			break
+		}
+	}
+}
+
+func TestFitLogisticRegressionSaturated(t *testing.T) {
+	features, _ := InitTensor64(4, 1)
+	features.Data = []float64{-2.0, -1.0, 1.0, 2.0}
+
+	targets, _ := InitTensor64(4, 1)
+	targets.Data = []float64{0.0, 0.0, 1.0, 1.0}
+
+	// a huge learning rate on separable data pushes the logits far past the
+	// range where sigmoid rounds to exactly 0 or 1
+	model, _ := InitLogisticRegression[float64, uint64](1, 0.0, 1000.0, 200)
+
+	err := model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("Fit aborted on saturated predictions: %v\n", err)
+	}
+
+	for n, cost := range model.CostHistory {
+		if math.IsNaN(cost) || math.IsInf(cost, 0) {
+			t.Errorf("Non-finite cost at iteration %v: %v", n, cost)
+			break
+		}
+	}
+}
+
+func BenchmarkLogisticRegressionFit(b *testing.B) {
+	features, targets, _ := MakeClassification[float64, uint64](1000, 4, 2, 1.0, 1)
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		model, _ := InitLogisticRegression[float64, uint64](4, 0.0, 0.01, 20)
+		model.Fit(features, targets)
+	}
+}
@@ -0,0 +1,345 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Every elementwise function below has an Into variant that writes into an
+// existing contiguous dst with the same number of elements instead of
+// allocating. dst may be the input itself, which updates it in place.
+
+// intoSlices returns the input values and the matching span of dst.
+func intoSlices[T Numeric, S Index](dst, input *Tensor[T, S]) ([]T, []T, error) {
+	src, err := input.elements()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !dst.IsContiguous() {
+		return nil, nil, errors.New("Destination tensor must be contiguous")
+	}
+
+	if len(dst.Data) < len(src) || !sameElementCount(dst.Shape, input.Shape) {
+		return nil, nil, fmt.Errorf("Destination shape %v does not match input shape %v", dst.Shape, input.Shape)
+	}
+
+	return src, dst.Data[:len(src)], nil
+}
+
+// binaryIntoSlices is intoSlices for functions of two tensors, which must
+// have the same shape. Only dst may differ in shape, as long as it holds the
+// same number of elements.
+func binaryIntoSlices[T Numeric, S Index](dst, a, b *Tensor[T, S]) ([]T, []T, []T, error) {
+	if !slices.Equal(a.Shape, b.Shape) {
+		return nil, nil, nil, fmt.Errorf("Tensor shapes do not match: %v != %v", a.Shape, b.Shape)
+	}
+
+	srcB, err := b.elements()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	srcA, out, err := intoSlices(dst, a)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return srcA, srcB, out, nil
+}
+
+func sameElementCount[S Index](a, b []S) bool {
+	countA := S(1)
+	for _, dim := range a {
+		countA *= dim
+	}
+
+	countB := S(1)
+	for _, dim := range b {
+		countB *= dim
+	}
+
+	return countA == countB
+}
+
+// unary allocates the result of an elementwise function and fills it with
+// into.
+func unary[T Numeric, S Index](input *Tensor[T, S], into func(dst, input *Tensor[T, S]) error) (*Tensor[T, S], error) {
+	result, err := InitTensor[T, S](input.Shape)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	err = into(result, input)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return result, nil
+}
+
+func Exp[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, ExpInto[T, S])
+}
+
+func ExpInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = T(math.Exp(float64(val)))
+	}
+
+	return nil
+}
+
+// Pow raises every element to exponent.
+func Pow[T Numeric, S Index](input *Tensor[T, S], exponent float64) (*Tensor[T, S], error) {
+	return unary(input, func(dst, input *Tensor[T, S]) error {
+		return PowInto(dst, input, exponent)
+	})
+}
+
+func PowInto[T Numeric, S Index](dst, input *Tensor[T, S], exponent float64) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	switch exponent {
+	case 2.0:
+		// squaring is common enough to skip math.Pow
+		for n, val := range src {
+			out[n] = val * val
+		}
+	case 0.5:
+		for n, val := range src {
+			out[n] = T(math.Sqrt(float64(val)))
+		}
+	default:
+		for n, val := range src {
+			out[n] = T(math.Pow(float64(val), exponent))
+		}
+	}
+
+	return nil
+}
+
+func Abs[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, AbsInto[T, S])
+}
+
+func AbsInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		if val < 0 {
+			val = -val
+		}
+		out[n] = val
+	}
+
+	return nil
+}
+
+func Tanh[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, TanhInto[T, S])
+}
+
+func TanhInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = T(math.Tanh(float64(val)))
+	}
+
+	return nil
+}
+
+func ReLU[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, ReLUInto[T, S])
+}
+
+func ReLUInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		if val < 0 {
+			val = 0
+		}
+		out[n] = val
+	}
+
+	return nil
+}
+
+func SoftplusInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = T(stableSoftplus(float64(val)))
+	}
+
+	return nil
+}
+
+// Clip limits every element to [low, high].
+func Clip[T Numeric, S Index](input *Tensor[T, S], low, high T) (*Tensor[T, S], error) {
+	return unary(input, func(dst, input *Tensor[T, S]) error {
+		return ClipInto(dst, input, low, high)
+	})
+}
+
+func ClipInto[T Numeric, S Index](dst, input *Tensor[T, S], low, high T) error {
+	if low > high {
+		return fmt.Errorf("Clip range must not be decreasing, got [%v, %v]", low, high)
+	}
+
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = min(max(val, low), high)
+	}
+
+	return nil
+}
+
+func Floor[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, FloorInto[T, S])
+}
+
+func FloorInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = T(math.Floor(float64(val)))
+	}
+
+	return nil
+}
+
+func Ceil[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, CeilInto[T, S])
+}
+
+func CeilInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = T(math.Ceil(float64(val)))
+	}
+
+	return nil
+}
+
+// Round rounds half away from zero, as math.Round does.
+func Round[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, RoundInto[T, S])
+}
+
+func RoundInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range src {
+		out[n] = T(math.Round(float64(val)))
+	}
+
+	return nil
+}
+
+// Sign maps every element to -1, 0 or 1. NaN stays NaN.
+func Sign[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(input, SignInto[T, S])
+}
+
+func SignInto[T Numeric, S Index](dst, input *Tensor[T, S]) error {
+	src, out, err := intoSlices(dst, input)
+	if err != nil {
+		return err
+	}
+
+	one := T(1.0)
+	for n, val := range src {
+		switch {
+		case val > 0:
+			out[n] = one
+		case val < 0:
+			out[n] = -one
+		case val == 0:
+			out[n] = 0
+		default:
+			out[n] = val
+		}
+	}
+
+	return nil
+}
+
+// Maximum takes the larger of a and b at every position. a and b must have
+// the same shape.
+func Maximum[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(a, func(dst, a *Tensor[T, S]) error {
+		return MaximumInto(dst, a, b)
+	})
+}
+
+func MaximumInto[T Numeric, S Index](dst, a, b *Tensor[T, S]) error {
+	srcA, srcB, out, err := binaryIntoSlices(dst, a, b)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range srcA {
+		out[n] = max(val, srcB[n])
+	}
+
+	return nil
+}
+
+// Minimum takes the smaller of a and b at every position.
+func Minimum[T Numeric, S Index](a, b *Tensor[T, S]) (*Tensor[T, S], error) {
+	return unary(a, func(dst, a *Tensor[T, S]) error {
+		return MinimumInto(dst, a, b)
+	})
+}
+
+func MinimumInto[T Numeric, S Index](dst, a, b *Tensor[T, S]) error {
+	srcA, srcB, out, err := binaryIntoSlices(dst, a, b)
+	if err != nil {
+		return err
+	}
+
+	for n, val := range srcA {
+		out[n] = min(val, srcB[n])
+	}
+
+	return nil
+}
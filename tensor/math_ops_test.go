@@ -0,0 +1,175 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestElementwiseMathOps(t *testing.T) {
+	input, _ := InitTensor64(2, 3)
+	input.Data = []float64{-2.5, -0.5, 0.0, 0.5, 1.5, 3.0}
+
+	unaryOps := map[string]struct {
+		op		func(*Tensor[float64, uint64]) (*Tensor[float64, uint64], error)
+		expected	func(float64) float64
+	} {
+		"Exp":		{Exp[float64, uint64], math.Exp},
+		"Abs":		{Abs[float64, uint64], math.Abs},
+		"Tanh":		{Tanh[float64, uint64], math.Tanh},
+		"ReLU":		{ReLU[float64, uint64], func(x float64) float64 { return math.Max(x, 0) }},
+		"Softplus":	{Softplus[float64, uint64], func(x float64) float64 { return math.Log(1 + math.Exp(x)) }},
+		"Floor":	{Floor[float64, uint64], math.Floor},
+		"Ceil":		{Ceil[float64, uint64], math.Ceil},
+		"Round":	{Round[float64, uint64], math.Round},
+	}
+
+	for name, test := range unaryOps {
+		result, err := test.op(input)
+		if err != nil {
+			t.Fatalf("%v failed: %v", name, err)
+		}
+
+		if !reflect.DeepEqual(result.Shape, input.Shape) {
+			t.Errorf("%v changed the shape to %v", name, result.Shape)
+		}
+
+		for n, val := range input.Data {
+			if math.Abs(result.Data[n] - test.expected(val)) > 1e-12 {
+				t.Errorf("%v(%v): got %v, expected %v", name, val, result.Data[n], test.expected(val))
+			}
+		}
+	}
+
+	squared, _ := Pow(input, 2.0)
+	cubed, _ := Pow(input, 3.0)
+	for n, val := range input.Data {
+		if squared.Data[n] != val * val || math.Abs(cubed.Data[n] - val * val * val) > 1e-12 {
+			t.Errorf("Unexpected powers of %v: %v %v", val, squared.Data[n], cubed.Data[n])
+		}
+	}
+
+	clipped, _ := Clip(input, -1.0, 1.0)
+	if !reflect.DeepEqual(clipped.Data, []float64{-1.0, -0.5, 0.0, 0.5, 1.0, 1.0}) {
+		t.Errorf("Unexpected Clip result: %v", clipped.Data)
+	}
+
+	_, err := Clip(input, 1.0, -1.0)
+	if err == nil {
+		t.Errorf("Expected an error for a decreasing clip range")
+	}
+
+	input.Data[2] = math.NaN()
+	signs, _ := Sign(input)
+	if signs.Data[0] != -1.0 || !math.IsNaN(signs.Data[2]) || signs.Data[5] != 1.0 {
+		t.Errorf("Unexpected Sign result: %v", signs.Data)
+	}
+
+	// integer tensors use the same functions
+	ints, _ := InitTensor[int, uint]([]uint{3})
+	ints.Data = []int{-3, 0, 4}
+	absInts, _ := Abs(ints)
+	signInts, _ := Sign(ints)
+	if !reflect.DeepEqual(absInts.Data, []int{3, 0, 4}) || !reflect.DeepEqual(signInts.Data, []int{-1, 0, 1}) {
+		t.Errorf("Unexpected integer results: %v %v", absInts.Data, signInts.Data)
+	}
+}
+
+func TestMaximumMinimum(t *testing.T) {
+	a, _ := InitTensor64(2, 2)
+	a.Data = []float64{1.0, 5.0, -2.0, 0.0}
+	b, _ := InitTensor64(2, 2)
+	b.Data = []float64{3.0, 4.0, -1.0, 0.0}
+
+	maximum, err := Maximum(a, b)
+	if err != nil || !reflect.DeepEqual(maximum.Data, []float64{3.0, 5.0, -1.0, 0.0}) {
+		t.Errorf("Unexpected Maximum: %v (%v)", maximum.Data, err)
+	}
+
+	minimum, err := Minimum(a, b)
+	if err != nil || !reflect.DeepEqual(minimum.Data, []float64{1.0, 4.0, -2.0, 0.0}) {
+		t.Errorf("Unexpected Minimum: %v (%v)", minimum.Data, err)
+	}
+
+	wrongShape, _ := InitTensor64(3, 1)
+	_, err = Maximum(a, wrongShape)
+	if err == nil {
+		t.Errorf("Expected an error for mismatched shapes")
+	}
+
+	// the same number of elements in a different layout is not the same shape
+	wide, _ := InitTensor64(2, 3)
+	tall, _ := InitTensor64(3, 2)
+	_, err = Minimum(wide, tall)
+	if err == nil {
+		t.Errorf("Expected an error for a [2 3] tensor against a [3 2] one")
+	}
+
+	dst, _ := InitTensor64(6)
+	err = MaximumInto(dst, wide, tall)
+	if err == nil {
+		t.Errorf("Expected MaximumInto to reject mismatched input shapes")
+	}
+}
+
+func TestMathOpsInto(t *testing.T) {
+	input, _ := InitTensor64(2, 2)
+	input.Data = []float64{-1.0, 2.0, -3.0, 4.0}
+
+	// writing into the input updates it in place
+	err := ReLUInto(input, input)
+	if err != nil || !reflect.DeepEqual(input.Data, []float64{0.0, 2.0, 0.0, 4.0}) {
+		t.Errorf("Unexpected in-place ReLU: %v (%v)", input.Data, err)
+	}
+
+	// a transposed view is read in its logical order
+	matrix, _ := InitTensor64(2, 3)
+	matrix.Data = []float64{1, 2, 3, 4, 5, 6}
+	transposed, _ := matrix.Transpose()
+
+	dst, _ := InitTensor64(3, 2)
+	err = PowInto(dst, transposed, 2.0)
+	if err != nil || !reflect.DeepEqual(dst.Data, []float64{1, 16, 4, 25, 9, 36}) {
+		t.Errorf("Unexpected PowInto on a view: %v (%v)", dst.Data, err)
+	}
+
+	// a row view only covers its own elements
+	row, _ := matrix.GetBatchSlice(0, 1)
+	rowDst, _ := InitTensor64(1, 3)
+	ExpInto(rowDst, row)
+	if math.Abs(rowDst.Data[2] - math.Exp(3)) > 1e-12 {
+		t.Errorf("Unexpected ExpInto on a row view: %v", rowDst.Data)
+	}
+
+	err = ExpInto(dst, input)
+	if err == nil {
+		t.Errorf("Expected an error for a destination of the wrong shape")
+	}
+
+	err = ExpInto(transposed, dst)
+	if err == nil {
+		t.Errorf("Expected an error for a non-contiguous destination")
+	}
+}
+
+func BenchmarkExp(b *testing.B) {
+	input, _ := InitRandomTensor64(1.0, 1000, 100)
+	dst, _ := InitTensor64(1000, 100)
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		ExpInto(dst, input)
+	}
+}
+
+func BenchmarkExpElementWiseApply(b *testing.B) {
+	input, _ := InitRandomTensor64(1.0, 1000, 100)
+	exp := func(val float64) float64 {
+		return math.Exp(val)
+	}
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		ElementWiseApply(input, exp)
+	}
+}
@@ -0,0 +1,595 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+type Activation int
+
+const (
+	IdentityActivation Activation = iota
+	SigmoidActivation
+	ReLUActivation
+	TanhActivation
+	SoftmaxActivation
+)
+
+type LossFunction int
+
+const (
+	MeanSquaredErrorLoss LossFunction = iota
+	BinaryCrossEntropyLoss
+	CategoricalCrossEntropyLoss
+)
+
+type DenseLayer[T Numeric, S Index] struct {
+	Weights		*Tensor[T, S]
+	Bias		[]T
+	Activation	Activation
+}
+
+// InitDenseLayer creates a fully connected layer. ReLU layers use He
+// initialization and every other activation uses Xavier (Glorot)
+// initialization, both drawn uniformly from r.
+func InitDenseLayer[T Numeric, S Index](
+	numInputs S,
+	numOutputs S,
+	activation Activation,
+	r *rand.Rand) (*DenseLayer[T, S], error) {
+
+	if numInputs == 0 || numOutputs == 0 {
+		return &DenseLayer[T, S]{}, errors.New("Dense layers require at least one input and one output")
+	}
+
+	if activation < IdentityActivation || activation > SoftmaxActivation {
+		return &DenseLayer[T, S]{}, fmt.Errorf("Unknown activation %v", activation)
+	}
+
+	initializer := XavierUniformInitializer
+	if activation == ReLUActivation {
+		initializer = HeUniformInitializer
+	}
+
+	weights, err := InitWeightTensor[T, S]([]S{numInputs, numOutputs}, initializer, r)
+	if err != nil {
+		return &DenseLayer[T, S]{}, err
+	}
+
+	layer := &DenseLayer[T, S] {
+		Weights:	weights,
+		Bias:		make([]T, numOutputs),
+		Activation:	activation,
+	}
+
+	return layer, nil
+}
+
+func (layer *DenseLayer[T, S]) Forward(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	z, err := input.Dot(layer.Weights)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	numOutputs := S(len(layer.Bias))
+	for n := range z.Data {
+		z.Data[n] += layer.Bias[S(n) % numOutputs]
+	}
+
+	return activate(layer.Activation, z)
+}
+
+func activate[T Numeric, S Index](activation Activation, z *Tensor[T, S]) (*Tensor[T, S], error) {
+	switch activation {
+	case IdentityActivation:
+		return z, nil
+	case SigmoidActivation:
+		return Sigmoid(z)
+	case SoftmaxActivation:
+		return Softmax(z)
+	case ReLUActivation, TanhActivation:
+		into := ReLUInto[T, S]
+		if activation == TanhActivation {
+			into = TanhInto[T, S]
+		}
+
+		err := into(z, z)
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+		return z, nil
+	}
+
+	return &Tensor[T, S]{}, fmt.Errorf("Unknown activation %v", activation)
+}
+
+// activationDelta converts the gradient of the loss with respect to the
+// layer's output into the gradient with respect to its pre-activation,
+// using only the activated output.
+func (layer *DenseLayer[T, S]) activationDelta(output, gradOutput *Tensor[T, S]) (*Tensor[T, S], error) {
+	delta, err := InitTensor[T, S](output.Shape)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	switch layer.Activation {
+	case IdentityActivation:
+		copy(delta.Data, gradOutput.Data)
+	case SigmoidActivation:
+		for n, a := range output.Data {
+			delta.Data[n] = gradOutput.Data[n] * a * (1 - a)
+		}
+	case ReLUActivation:
+		for n, a := range output.Data {
+			if a > 0 {
+				delta.Data[n] = gradOutput.Data[n]
+			}
+		}
+	case TanhActivation:
+		for n, a := range output.Data {
+			delta.Data[n] = gradOutput.Data[n] * (1 - a * a)
+		}
+	case SoftmaxActivation:
+		// the softmax Jacobian gives delta_i = a_i * (g_i - sum_j g_j * a_j)
+		numOutputs := int(output.Shape[1])
+		for rowStart := 0; rowStart < len(output.Data); rowStart += numOutputs {
+			weighted := T(0)
+			for c := 0; c < numOutputs; c++ {
+				weighted += gradOutput.Data[rowStart + c] * output.Data[rowStart + c]
+			}
+
+			for c := 0; c < numOutputs; c++ {
+				a := output.Data[rowStart + c]
+				delta.Data[rowStart + c] = a * (gradOutput.Data[rowStart + c] - weighted)
+			}
+		}
+	default:
+		return &Tensor[T, S]{}, fmt.Errorf("Unknown activation %v", layer.Activation)
+	}
+
+	return delta, nil
+}
+
+// backward returns the weight and bias gradients for a layer given its input
+// and the gradient with respect to its pre-activation, along with the
+// gradient to pass on to the previous layer.
+func (layer *DenseLayer[T, S]) backward(input, delta *Tensor[T, S]) (*Tensor[T, S], []T, *Tensor[T, S], error) {
+	transposedInput, err := input.Transpose()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	gradWeights, err := transposedInput.Dot(delta)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	numOutputs := S(len(layer.Bias))
+	gradBias := make([]T, numOutputs)
+	for n, val := range delta.Data {
+		gradBias[S(n) % numOutputs] += val
+	}
+
+	transposedWeights, err := layer.Weights.Transpose()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	gradInput, err := delta.Dot(transposedWeights)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return gradWeights, gradBias, gradInput, nil
+}
+
+type MLP[T Numeric, S Index] struct {
+	LayerSizes		[]S
+	HiddenActivation	Activation
+	OutputActivation	Activation
+	Loss			LossFunction
+	LearningRate		T
+	NumEpochs		S
+	BatchSize		S
+	Seed			int64
+	Regularization		Regularization[T]
+	Layers			[]*DenseLayer[T, S]
+	LossHistory		[]T
+	WarmStart		bool
+}
+
+func validateLayerSizes[S Index](layerSizes []S) error {
+	if len(layerSizes) < 2 {
+		return errors.New("MLP requires at least an input and an output size")
+	}
+
+	for _, size := range layerSizes {
+		if size == 0 {
+			return errors.New("MLP layer sizes must be positive")
+		}
+	}
+
+	return nil
+}
+
+// InitMLP creates a feed-forward network. layerSizes lists the number of
+// features, then the width of every hidden layer, then the number of outputs.
+// Binary cross-entropy requires a sigmoid output and categorical
+// cross-entropy a softmax output, with targets in one-hot form.
+func InitMLP[T Numeric, S Index](
+	layerSizes []S,
+	hiddenActivation Activation,
+	outputActivation Activation,
+	loss LossFunction,
+	learningRate T,
+	numEpochs S) (*MLP[T, S], error) {
+
+	err := validateLayerSizes(layerSizes)
+	if err != nil {
+		return &MLP[T, S]{}, err
+	}
+
+	if loss == BinaryCrossEntropyLoss && outputActivation != SigmoidActivation {
+		return &MLP[T, S]{}, errors.New("Binary cross-entropy requires a sigmoid output activation")
+	}
+
+	if loss == CategoricalCrossEntropyLoss && outputActivation != SoftmaxActivation {
+		return &MLP[T, S]{}, errors.New("Categorical cross-entropy requires a softmax output activation")
+	}
+
+	if loss < MeanSquaredErrorLoss || loss > CategoricalCrossEntropyLoss {
+		return &MLP[T, S]{}, fmt.Errorf("Unknown loss function %v", loss)
+	}
+
+	model := &MLP[T, S] {
+		LayerSizes:		layerSizes,
+		HiddenActivation:	hiddenActivation,
+		OutputActivation:	outputActivation,
+		Loss:			loss,
+		LearningRate:		learningRate,
+		NumEpochs:		numEpochs,
+		BatchSize:		32,
+		LossHistory:		make([]T, 0, numEpochs),
+	}
+
+	err = model.initLayers()
+	if err != nil {
+		return &MLP[T, S]{}, err
+	}
+
+	return model, nil
+}
+
+// initLayers draws fresh weights for every layer. Fit calls it again unless
+// WarmStart is set, so setting Seed after InitMLP still makes training
+// reproducible.
+func (model *MLP[T, S]) initLayers() error {
+	r := newSeededRand(model.Seed)
+
+	numLayers := len(model.LayerSizes) - 1
+	model.Layers = make([]*DenseLayer[T, S], numLayers)
+
+	for n := 0; n < numLayers; n++ {
+		activation := model.HiddenActivation
+		if n == numLayers - 1 {
+			activation = model.OutputActivation
+		}
+
+		layer, err := InitDenseLayer[T, S](model.LayerSizes[n], model.LayerSizes[n + 1], activation, r)
+		if err != nil {
+			return fmt.Errorf("Failed to create layer %v: %v", n, err)
+		}
+
+		model.Layers[n] = layer
+	}
+
+	return nil
+}
+
+// checkLayers verifies that the current Layers match LayerSizes before a warm
+// start trains them.
+func (model *MLP[T, S]) checkLayers() error {
+	numLayers := len(model.LayerSizes) - 1
+	if len(model.Layers) != numLayers {
+		return fmt.Errorf("WarmStart expects %v layers for sizes %v, got %v", numLayers, model.LayerSizes, len(model.Layers))
+	}
+
+	for n, layer := range model.Layers {
+		shape := []S{model.LayerSizes[n], model.LayerSizes[n + 1]}
+		if layer == nil || layer.Weights == nil || !slices.Equal(layer.Weights.Shape, shape) || S(len(layer.Bias)) != shape[1] {
+			return fmt.Errorf("WarmStart expects layer %v to have weights of shape %v", n, shape)
+		}
+	}
+
+	return nil
+}
+
+// forward returns the input followed by the output of every layer.
+func (model *MLP[T, S]) forward(input *Tensor[T, S]) ([]*Tensor[T, S], error) {
+	outputs := make([]*Tensor[T, S], 0, len(model.Layers) + 1)
+	outputs = append(outputs, input)
+
+	current := input
+	for n, layer := range model.Layers {
+		next, err := layer.Forward(current)
+		if err != nil {
+			return nil, fmt.Errorf("Forward pass failed at layer %v: %v", n, err)
+		}
+
+		outputs = append(outputs, next)
+		current = next
+	}
+
+	return outputs, nil
+}
+
+func (model *MLP[T, S]) cost(predicted, expected *Tensor[T, S]) (T, error) {
+	switch model.Loss {
+	case BinaryCrossEntropyLoss:
+		return CalculateCost(predicted, expected)
+	case CategoricalCrossEntropyLoss:
+		return CategoricalCrossEntropy(predicted, expected)
+	}
+
+	total := 0.0
+	for n := range predicted.Data {
+		diff := float64(predicted.Data[n]) - float64(expected.Data[n])
+		total += diff * diff
+	}
+
+	return T(0.5 * total / float64(len(predicted.Data))), nil
+}
+
+// outputDelta returns the gradient of the loss with respect to the output
+// layer's pre-activation. Cross-entropy paired with its matching activation
+// reduces to (a - y), which avoids dividing by a saturated derivative.
+func (model *MLP[T, S]) outputDelta(output, targets *Tensor[T, S]) (*Tensor[T, S], error) {
+	errorTerm, err := output.Subtract(targets)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	scale := T(1.0) / T(len(output.Data))
+	if model.Loss == CategoricalCrossEntropyLoss {
+		scale = T(1.0) / T(output.Shape[0])
+	}
+
+	err = errorTerm.ScaleInPlace(scale)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	if model.Loss == CategoricalCrossEntropyLoss || model.Loss == BinaryCrossEntropyLoss {
+		return errorTerm, nil
+	}
+
+	return model.Layers[len(model.Layers) - 1].activationDelta(output, errorTerm)
+}
+
+// Fit trains the network with mini-batch gradient descent. Targets must have
+// shape [N, outputs]; use one-hot rows with categorical cross-entropy. Fit
+// draws new weights from Seed, unless WarmStart is set and Layers is not
+// empty, in which case it continues training the current Layers.
+func (model *MLP[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	err := model.Regularization.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = validateLayerSizes(model.LayerSizes)
+	if err != nil {
+		return err
+	}
+
+	numInputs := model.LayerSizes[0]
+	numOutputs := model.LayerSizes[len(model.LayerSizes) - 1]
+
+	if len(features.Shape) != 2 || features.Shape[1] != numInputs {
+		return fmt.Errorf("MLP expects features of shape [N, %v], got %v", numInputs, features.Shape)
+	}
+
+	numSamples := features.Shape[0]
+	if numSamples == 0 {
+		return errors.New("MLP Fit requires at least one sample")
+	}
+
+	if len(targets.Shape) != 2 || targets.Shape[0] != numSamples || targets.Shape[1] != numOutputs {
+		return fmt.Errorf("MLP expects targets of shape [%v, %v], got %v", numSamples, numOutputs, targets.Shape)
+	}
+
+	if model.WarmStart && len(model.Layers) > 0 {
+		err = model.checkLayers()
+	} else {
+		err = model.initLayers()
+	}
+	if err != nil {
+		return err
+	}
+
+	contiguousFeatures, err := features.Contiguous()
+	if err != nil {
+		return err
+	}
+
+	contiguousTargets, err := targets.Contiguous()
+	if err != nil {
+		return err
+	}
+
+	// ShuffleTensors swaps in new Data slices, so shuffle shallow copies to
+	// keep the caller's tensors in their original order
+	shuffledFeatures := &Tensor[T, S]{Shape: contiguousFeatures.Shape, Strides: contiguousFeatures.Strides, Data: contiguousFeatures.Data}
+	shuffledTargets := &Tensor[T, S]{Shape: contiguousTargets.Shape, Strides: contiguousTargets.Strides, Data: contiguousTargets.Data}
+
+	batchSize := model.BatchSize
+	if batchSize == 0 {
+		batchSize = numSamples
+	}
+	numBatches := (numSamples + batchSize - 1) / batchSize
+
+	model.LossHistory = make([]T, 0, model.NumEpochs)
+
+	for epoch := S(0); epoch < model.NumEpochs; epoch++ {
+		err = ShuffleTensors(shuffledFeatures, shuffledTargets)
+		if err != nil {
+			return err
+		}
+
+		for batchNum := S(0); batchNum < numBatches; batchNum++ {
+			startRow := batchNum * batchSize
+			batchSampleCount := min(startRow + batchSize, numSamples) - startRow
+
+			featureBatch, err := shuffledFeatures.GetBatchSlice(startRow, batchSampleCount)
+			if err != nil {
+				return err
+			}
+
+			targetBatch, err := shuffledTargets.GetBatchSlice(startRow, batchSampleCount)
+			if err != nil {
+				return err
+			}
+
+			err = model.step(featureBatch, targetBatch)
+			if err != nil {
+				return fmt.Errorf("MLP training failed in epoch %v: %v", epoch, err)
+			}
+		}
+
+		predictions, err := model.Predict(features)
+		if err != nil {
+			return err
+		}
+
+		cost, err := model.cost(predictions, contiguousTargets)
+		if err != nil {
+			return err
+		}
+
+		penaltyCost := T(0)
+		for _, layer := range model.Layers {
+			layerPenalty, err := PenaltyCost(model.Regularization, layer.Weights, false)
+			if err != nil {
+				return err
+			}
+			penaltyCost += layerPenalty
+		}
+
+		totalCost := cost + penaltyCost
+		if math.IsNaN(float64(totalCost)) || math.IsInf(float64(totalCost), 0) {
+			return fmt.Errorf("NaN or Inf loss in MLP at epoch %v", epoch)
+		}
+
+		model.LossHistory = append(model.LossHistory, totalCost)
+	}
+
+	return nil
+}
+
+// step runs one forward and backward pass over a batch and applies the
+// gradient descent update to every layer.
+func (model *MLP[T, S]) step(featureBatch, targetBatch *Tensor[T, S]) error {
+	outputs, err := model.forward(featureBatch)
+	if err != nil {
+		return err
+	}
+
+	delta, err := model.outputDelta(outputs[len(outputs) - 1], targetBatch)
+	if err != nil {
+		return err
+	}
+
+	for n := len(model.Layers) - 1; n >= 0; n-- {
+		layer := model.Layers[n]
+
+		gradWeights, gradBias, gradInput, err := layer.backward(outputs[n], delta)
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			delta, err = model.Layers[n - 1].activationDelta(outputs[n], gradInput)
+			if err != nil {
+				return err
+			}
+		}
+
+		// gradWeights is a fresh tensor from backward, so update it in place
+		err = AddPenaltyGradient(model.Regularization, layer.Weights, gradWeights, false)
+		if err != nil {
+			return err
+		}
+
+		err = gradWeights.ScaleInPlace(model.LearningRate)
+		if err != nil {
+			return err
+		}
+
+		err = layer.Weights.SubtractInPlace(gradWeights)
+		if err != nil {
+			return err
+		}
+
+		for m, grad := range gradBias {
+			layer.Bias[m] -= model.LearningRate * grad
+		}
+	}
+
+	return nil
+}
+
+// Predict returns the [N, outputs] activations of the output layer.
+func (model *MLP[T, S]) Predict(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(model.Layers) == 0 {
+		return &Tensor[T, S]{}, errors.New("MLP has no layers (use InitMLP)")
+	}
+
+	numInputs := model.Layers[0].Weights.Shape[0]
+	if len(features.Shape) != 2 || features.Shape[1] != numInputs {
+		return &Tensor[T, S]{}, fmt.Errorf("MLP expects features of shape [N, %v], got %v", numInputs, features.Shape)
+	}
+
+	outputs, err := model.forward(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return outputs[len(outputs) - 1], nil
+}
+
+// PredictClasses returns the index of the predicted class for every row:
+// the arg max of the outputs, or a 0.5 threshold for a single sigmoid output.
+func (model *MLP[T, S]) PredictClasses(features *Tensor[T, S]) ([]S, error) {
+	outputs, err := model.Predict(features)
+	if err != nil {
+		return nil, err
+	}
+
+	numSamples := outputs.Shape[0]
+	numOutputs := outputs.Shape[1]
+
+	classes := make([]S, numSamples)
+	for n := S(0); n < numSamples; n++ {
+		row := outputs.Data[n * numOutputs: (n + 1) * numOutputs]
+
+		if numOutputs == 1 {
+			threshold := 0.5
+			if float64(row[0]) >= threshold {
+				classes[n] = 1
+			}
+			continue
+		}
+
+		best := S(0)
+		for c := S(1); c < numOutputs; c++ {
+			if row[c] > row[best] {
+				best = c
+			}
+		}
+		classes[n] = best
+	}
+
+	return classes, nil
+}
@@ -0,0 +1,265 @@
+package tensor
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestMLPGradientCheck(t *testing.T) {
+	features, _ := InitTensor64(4, 3)
+	features.Data = []float64{0.5, -1.0, 2.0, 1.5, 0.3, -0.7, -0.2, 0.8, 0.1, 1.0, -1.2, 0.4}
+
+	activations := []Activation{SigmoidActivation, TanhActivation, IdentityActivation}
+	losses := []LossFunction{MeanSquaredErrorLoss, MeanSquaredErrorLoss, BinaryCrossEntropyLoss, CategoricalCrossEntropyLoss}
+	outputs := []Activation{IdentityActivation, SoftmaxActivation, SigmoidActivation, SoftmaxActivation}
+
+	targets, _ := InitTensor64(4, 2)
+	targets.Data = []float64{1, 0, 0, 1, 0, 1, 1, 0}
+
+	for _, hidden := range activations {
+		for l, loss := range losses {
+			model, err := InitMLP[float64, uint64]([]uint64{3, 4, 2}, hidden, outputs[l], loss, 0.1, 1)
+			if err != nil {
+				t.Fatalf("InitMLP failed: %v\n", err)
+			}
+
+			layerOutputs, _ := model.forward(features)
+			delta, _ := model.outputDelta(layerOutputs[2], targets)
+			gradOuter, _, gradInput, _ := model.Layers[1].backward(layerOutputs[1], delta)
+			hiddenDelta, _ := model.Layers[0].activationDelta(layerOutputs[1], gradInput)
+			gradInner, _, _, _ := model.Layers[0].backward(layerOutputs[0], hiddenDelta)
+
+			analytic := []*Tensor[float64, uint64]{gradInner, gradOuter}
+
+			const h = 1e-6
+			for n, layer := range model.Layers {
+				for m := range layer.Weights.Data {
+					original := layer.Weights.Data[m]
+
+					layer.Weights.Data[m] = original + h
+					plus, _ := model.Predict(features)
+					costPlus, _ := model.cost(plus, targets)
+
+					layer.Weights.Data[m] = original - h
+					minus, _ := model.Predict(features)
+					costMinus, _ := model.cost(minus, targets)
+
+					layer.Weights.Data[m] = original
+
+					numeric := (costPlus - costMinus) / (2 * h)
+					if math.Abs(numeric - analytic[n].Data[m]) > 1e-6 {
+						t.Fatalf("Gradient mismatch (hidden %v, loss %v) at layer %v weight %v: numeric %v, analytic %v",
+							hidden, loss, n, m, numeric, analytic[n].Data[m])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMLPXOR(t *testing.T) {
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{0, 0, 0, 1, 1, 0, 1, 1}
+
+	targets, _ := InitTensor64(4, 1)
+	targets.Data = []float64{0, 1, 1, 0}
+
+	model, err := InitMLP[float64, uint64]([]uint64{2, 8, 1}, TanhActivation, SigmoidActivation, BinaryCrossEntropyLoss, 0.5, 2000)
+	if err != nil {
+		t.Fatalf("InitMLP failed: %v\n", err)
+	}
+	model.Seed = 4
+
+	err = model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("MLP Fit failed: %v\n", err)
+	}
+
+	classes, err := model.PredictClasses(features)
+	if err != nil {
+		t.Fatalf("MLP PredictClasses failed: %v\n", err)
+	}
+
+	for n, class := range classes {
+		if float64(class) != targets.Data[n] {
+			t.Errorf("Unexpected XOR predictions: %v", classes)
+			break
+		}
+	}
+
+	if model.LossHistory[len(model.LossHistory) - 1] >= model.LossHistory[0] {
+		t.Errorf("Expected the loss to decrease, got %v -> %v", model.LossHistory[0], model.LossHistory[len(model.LossHistory) - 1])
+	}
+
+	if features.Data[2] != 0 || targets.Data[1] != 1 {
+		t.Errorf("Fit should not reorder the caller's tensors")
+	}
+}
+
+func TestMLPSoftmaxClassification(t *testing.T) {
+	features, labels := threeClusterData()
+	classes, encoded := encodeLabels(labels)
+
+	targets, _ := InitTensor64(uint64(len(labels)), uint64(len(classes)))
+	for n, class := range encoded {
+		targets.Data[n * len(classes) + class] = 1.0
+	}
+
+	model, err := InitMLP[float64, uint64]([]uint64{2, 6, 3}, ReLUActivation, SoftmaxActivation, CategoricalCrossEntropyLoss, 0.05, 300)
+	if err != nil {
+		t.Fatalf("InitMLP failed: %v\n", err)
+	}
+	model.Seed = 8
+	model.BatchSize = 4
+
+	err = model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("MLP Fit failed: %v\n", err)
+	}
+
+	predicted, err := model.PredictClasses(features)
+	if err != nil {
+		t.Fatalf("MLP PredictClasses failed: %v\n", err)
+	}
+
+	for n, class := range predicted {
+		if int(class) != encoded[n] {
+			t.Errorf("Unexpected predictions: got %v, expected %v", predicted, encoded)
+			break
+		}
+	}
+}
+
+func TestMLPRegression(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+
+	features, _ := InitTensor64(100, 1)
+	targets, _ := InitTensor64(100, 1)
+	for n := range features.Data {
+		x := r.Float64() * 4.0 - 2.0
+		features.Data[n] = x
+		targets.Data[n] = x * x
+	}
+
+	model, err := InitMLP[float64, uint64]([]uint64{1, 16, 1}, TanhActivation, IdentityActivation, MeanSquaredErrorLoss, 0.1, 500)
+	if err != nil {
+		t.Fatalf("InitMLP failed: %v\n", err)
+	}
+	model.Seed = 12
+	model.BatchSize = 10
+
+	err = model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("MLP Fit failed: %v\n", err)
+	}
+
+	predictions, _ := model.Predict(features)
+	r2, _ := R2Score(targets, predictions)
+	if r2 < 0.95 {
+		t.Errorf("Expected R2 above 0.95 on a quadratic, got %v", r2)
+	}
+}
+
+func TestMLPErrors(t *testing.T) {
+	_, err := InitMLP[float64, uint64]([]uint64{2}, ReLUActivation, IdentityActivation, MeanSquaredErrorLoss, 0.1, 10)
+	if err == nil {
+		t.Errorf("Expected an error for a network without an output layer")
+	}
+
+	_, err = InitMLP[float64, uint64]([]uint64{2, 3}, ReLUActivation, IdentityActivation, CategoricalCrossEntropyLoss, 0.1, 10)
+	if err == nil {
+		t.Errorf("Expected an error for cross-entropy without softmax")
+	}
+
+	model, _ := InitMLP[float64, uint64]([]uint64{2, 3}, ReLUActivation, IdentityActivation, MeanSquaredErrorLoss, 0.1, 10)
+	features, _ := InitTensor64(4, 3)
+	targets, _ := InitTensor64(4, 3)
+
+	err = model.Fit(features, targets)
+	if err == nil {
+		t.Errorf("Expected an error for the wrong number of features")
+	}
+}
+
+func TestMLPWarmStart(t *testing.T) {
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{0, 0, 0, 1, 1, 0, 1, 1}
+
+	targets, _ := InitTensor64(4, 1)
+	targets.Data = []float64{0, 1, 1, 0}
+
+	newModel := func() *MLP[float64, uint64] {
+		model, _ := InitMLP[float64, uint64]([]uint64{2, 8, 1}, TanhActivation, SigmoidActivation, BinaryCrossEntropyLoss, 0.5, 200)
+		model.Seed = 4
+		return model
+	}
+
+	// setting Seed after InitMLP still gives reproducible starting weights
+	first := newModel()
+	second := newModel()
+	first.NumEpochs = 0
+	second.NumEpochs = 0
+	first.Fit(features, targets)
+	second.Fit(features, targets)
+	if !reflect.DeepEqual(first.Layers[0].Weights.Data, second.Layers[0].Weights.Data) {
+		t.Fatalf("Expected models with the same Seed to start from the same weights")
+	}
+
+	first.NumEpochs = 200
+	first.Fit(features, targets)
+
+	// a warm start continues from the trained weights
+	lastLoss := first.LossHistory[len(first.LossHistory) - 1]
+	first.WarmStart = true
+	err := first.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("Warm-started Fit failed: %v\n", err)
+	}
+	if first.LossHistory[0] > lastLoss * 1.01 {
+		t.Errorf("Expected a warm start near loss %v, got %v", lastLoss, first.LossHistory[0])
+	}
+
+	// hand-set layers are used as given
+	manual := newModel()
+	manual.NumEpochs = 0
+	manual.WarmStart = true
+	weights, _ := InitTensor64(2, 8)
+	for n := range weights.Data {
+		weights.Data[n] = 0.25
+	}
+	manual.Layers[0] = &DenseLayer[float64, uint64]{Weights: weights, Bias: make([]float64, 8), Activation: TanhActivation}
+	manual.Fit(features, targets)
+	if manual.Layers[0].Weights != weights {
+		t.Errorf("Expected a warm start to keep hand-set layers")
+	}
+
+	// without WarmStart, Fit draws new weights
+	manual.WarmStart = false
+	manual.Fit(features, targets)
+	if manual.Layers[0].Weights == weights {
+		t.Errorf("Expected Fit to draw new layers without WarmStart")
+	}
+
+	// layers that do not match LayerSizes cannot be warm-started
+	manual.WarmStart = true
+	manual.LayerSizes = []uint64{2, 4, 1}
+	err = manual.Fit(features, targets)
+	if err == nil {
+		t.Errorf("Expected an error for layers that do not match LayerSizes")
+	}
+
+	// an MLP built without InitMLP draws its layers on the first Fit
+	literal := &MLP[float64, uint64]{LayerSizes: []uint64{2, 4, 1}, HiddenActivation: TanhActivation, OutputActivation: SigmoidActivation, Loss: BinaryCrossEntropyLoss, LearningRate: 0.5, NumEpochs: 1, BatchSize: 4, WarmStart: true}
+	err = literal.Fit(features, targets)
+	if err != nil || len(literal.Layers) != 2 {
+		t.Errorf("Expected Fit to draw layers for an empty MLP: %v", err)
+	}
+
+	empty := &MLP[float64, uint64]{}
+	err = empty.Fit(features, targets)
+	if err == nil {
+		t.Errorf("Expected an error for an MLP without LayerSizes")
+	}
+}
@@ -0,0 +1,406 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type GaussianNB[T Numeric, S Index] struct {
+	VarSmoothing	T
+	Classes		[]string
+	ClassCounts	[]S
+	ClassLogPriors	[]T
+	Means		*Tensor[T, S]
+	Variances	*Tensor[T, S]
+}
+
+// InitGaussianNB creates a Gaussian Naive Bayes classifier. varSmoothing is
+// the fraction of the largest feature variance added to every variance for
+// stability; 1e-9 is a reasonable default.
+func InitGaussianNB[T Numeric, S Index](varSmoothing T) (*GaussianNB[T, S], error) {
+	if float64(varSmoothing) < 0 {
+		return &GaussianNB[T, S]{}, fmt.Errorf("GaussianNB variance smoothing must be non-negative, got %v", varSmoothing)
+	}
+
+	return &GaussianNB[T, S]{VarSmoothing: varSmoothing}, nil
+}
+
+// groupByClass encodes the labels and returns the row indices of each class.
+func groupByClass[S Index](labels []string, numSamples S) ([]string, [][]int, error) {
+	if numSamples == 0 {
+		return nil, nil, errors.New("Naive Bayes requires at least one sample")
+	}
+
+	if S(len(labels)) != numSamples {
+		return nil, nil, fmt.Errorf("Expecting %v labels, got %v", numSamples, len(labels))
+	}
+
+	classes, encoded := encodeLabels(labels)
+
+	groups := make([][]int, len(classes))
+	for n, class := range encoded {
+		groups[class] = append(groups[class], n)
+	}
+
+	return classes, groups, nil
+}
+
+func (model *GaussianNB[T, S]) Fit(features *Tensor[T, S], labels []string) error {
+	if len(features.Shape) != 2 {
+		return errors.New("GaussianNB requires a 2D feature tensor")
+	}
+
+	numSamples := features.Shape[0]
+	numFeatures := features.Shape[1]
+
+	classes, groups, err := groupByClass(labels, numSamples)
+	if err != nil {
+		return err
+	}
+
+	numClasses := S(len(classes))
+
+	means, err := InitTensor[T, S]([]S{numClasses, numFeatures})
+	if err != nil {
+		return err
+	}
+
+	variances, err := InitTensor[T, S]([]S{numClasses, numFeatures})
+	if err != nil {
+		return err
+	}
+
+	// the smoothing term is relative to the largest variance of any feature
+	// over the whole training set
+	_, overallVariances, err := columnMoments(features)
+	if err != nil {
+		return err
+	}
+
+	largestVariance := 0.0
+	for _, variance := range overallVariances {
+		largestVariance = math.Max(largestVariance, float64(variance))
+	}
+	epsilon := float64(model.VarSmoothing) * largestVariance
+
+	classCounts := make([]S, numClasses)
+	logPriors := make([]T, numClasses)
+
+	for c, group := range groups {
+		classFeatures, err := selectRows(features, group)
+		if err != nil {
+			return err
+		}
+
+		classMeans, classVariances, err := columnMoments(classFeatures)
+		if err != nil {
+			return err
+		}
+
+		for m := S(0); m < numFeatures; m++ {
+			variance := float64(classVariances[m]) + epsilon
+			if variance <= 0 {
+				return fmt.Errorf("Feature %v has zero variance within class %v; use a positive VarSmoothing", m, classes[c])
+			}
+
+			means.Data[S(c) * numFeatures + m] = classMeans[m]
+			variances.Data[S(c) * numFeatures + m] = T(variance)
+		}
+
+		classCounts[c] = S(len(group))
+		logPriors[c] = T(math.Log(float64(len(group)) / float64(numSamples)))
+	}
+
+	model.Classes = classes
+	model.ClassCounts = classCounts
+	model.ClassLogPriors = logPriors
+	model.Means = means
+	model.Variances = variances
+
+	return nil
+}
+
+func (model *GaussianNB[T, S]) jointLogLikelihood(features *Tensor[T, S]) ([]float64, error) {
+	if len(model.Classes) == 0 {
+		return nil, errors.New("GaussianNB must be fitted (call Fit)")
+	}
+
+	numFeatures := model.Means.Shape[1]
+	if len(features.Shape) != 2 || features.Shape[1] != numFeatures {
+		return nil, fmt.Errorf("GaussianNB expects shape [N, %v], got %v", numFeatures, features.Shape)
+	}
+
+	points, err := features.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+
+	numSamples := points.Shape[0]
+	numClasses := S(len(model.Classes))
+
+	likelihoods := make([]float64, numSamples * numClasses)
+	for n := S(0); n < numSamples; n++ {
+		row := points.Data[n * numFeatures: (n + 1) * numFeatures]
+
+		for c := S(0); c < numClasses; c++ {
+			total := float64(model.ClassLogPriors[c])
+
+			for m := S(0); m < numFeatures; m++ {
+				variance := float64(model.Variances.Data[c * numFeatures + m])
+				diff := float64(row[m]) - float64(model.Means.Data[c * numFeatures + m])
+				total -= 0.5 * (math.Log(2.0 * math.Pi * variance) + diff * diff / variance)
+			}
+
+			likelihoods[n * numClasses + c] = total
+		}
+	}
+
+	return likelihoods, nil
+}
+
+// PredictLogProba returns an [N, C] tensor of log class probabilities whose
+// columns follow the order of Classes.
+func (model *GaussianNB[T, S]) PredictLogProba(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	likelihoods, err := model.jointLogLikelihood(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return normalizeLogLikelihoods[T, S](likelihoods, S(len(model.Classes)))
+}
+
+func (model *GaussianNB[T, S]) PredictProba(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	logProbabilities, err := model.PredictLogProba(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return expLogProbabilities(logProbabilities), nil
+}
+
+func (model *GaussianNB[T, S]) Predict(features *Tensor[T, S]) ([]string, error) {
+	likelihoods, err := model.jointLogLikelihood(features)
+	if err != nil {
+		return nil, err
+	}
+
+	return mostLikelyClasses(likelihoods, model.Classes), nil
+}
+
+type MultinomialNB[T Numeric, S Index] struct {
+	Alpha		T
+	Classes		[]string
+	ClassCounts	[]S
+	ClassLogPriors	[]T
+	FeatureCounts	*Tensor[T, S]
+	FeatureLogProb	*Tensor[T, S]
+}
+
+// InitMultinomialNB creates a Naive Bayes classifier for non-negative count
+// features such as word counts. alpha is the additive (Laplace/Lidstone)
+// smoothing applied to every feature count.
+func InitMultinomialNB[T Numeric, S Index](alpha T) (*MultinomialNB[T, S], error) {
+	if float64(alpha) < 0 {
+		return &MultinomialNB[T, S]{}, fmt.Errorf("MultinomialNB alpha must be non-negative, got %v", alpha)
+	}
+
+	return &MultinomialNB[T, S]{Alpha: alpha}, nil
+}
+
+func (model *MultinomialNB[T, S]) Fit(features *Tensor[T, S], labels []string) error {
+	if len(features.Shape) != 2 {
+		return errors.New("MultinomialNB requires a 2D feature tensor")
+	}
+
+	numSamples := features.Shape[0]
+	numFeatures := features.Shape[1]
+
+	classes, groups, err := groupByClass(labels, numSamples)
+	if err != nil {
+		return err
+	}
+
+	points, err := features.Contiguous()
+	if err != nil {
+		return err
+	}
+
+	for _, val := range points.Data {
+		if val < 0 {
+			return errors.New("MultinomialNB requires non-negative feature counts")
+		}
+	}
+
+	numClasses := S(len(classes))
+
+	featureCounts, err := InitTensor[T, S]([]S{numClasses, numFeatures})
+	if err != nil {
+		return err
+	}
+
+	featureLogProb, err := InitTensor[T, S]([]S{numClasses, numFeatures})
+	if err != nil {
+		return err
+	}
+
+	alpha := float64(model.Alpha)
+	classCounts := make([]S, numClasses)
+	logPriors := make([]T, numClasses)
+
+	for c, rows := range groups {
+		counts := featureCounts.Data[S(c) * numFeatures: S(c + 1) * numFeatures]
+		for _, row := range rows {
+			for m := S(0); m < numFeatures; m++ {
+				counts[m] += points.Data[S(row) * numFeatures + m]
+			}
+		}
+
+		total := 0.0
+		for _, count := range counts {
+			total += float64(count) + alpha
+		}
+
+		for m, count := range counts {
+			smoothed := float64(count) + alpha
+			if smoothed == 0 || total == 0 {
+				return fmt.Errorf("Feature %v never occurs in class %v; use a positive alpha", m, classes[c])
+			}
+			featureLogProb.Data[S(c) * numFeatures + S(m)] = T(math.Log(smoothed / total))
+		}
+
+		classCounts[c] = S(len(rows))
+		logPriors[c] = T(math.Log(float64(len(rows)) / float64(numSamples)))
+	}
+
+	model.Classes = classes
+	model.ClassCounts = classCounts
+	model.ClassLogPriors = logPriors
+	model.FeatureCounts = featureCounts
+	model.FeatureLogProb = featureLogProb
+
+	return nil
+}
+
+func (model *MultinomialNB[T, S]) jointLogLikelihood(features *Tensor[T, S]) ([]float64, error) {
+	if len(model.Classes) == 0 {
+		return nil, errors.New("MultinomialNB must be fitted (call Fit)")
+	}
+
+	numFeatures := model.FeatureLogProb.Shape[1]
+	if len(features.Shape) != 2 || features.Shape[1] != numFeatures {
+		return nil, fmt.Errorf("MultinomialNB expects shape [N, %v], got %v", numFeatures, features.Shape)
+	}
+
+	points, err := features.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+
+	numSamples := points.Shape[0]
+	numClasses := S(len(model.Classes))
+
+	likelihoods := make([]float64, numSamples * numClasses)
+	for n := S(0); n < numSamples; n++ {
+		row := points.Data[n * numFeatures: (n + 1) * numFeatures]
+
+		for c := S(0); c < numClasses; c++ {
+			total := float64(model.ClassLogPriors[c])
+			for m, count := range row {
+				total += float64(count) * float64(model.FeatureLogProb.Data[c * numFeatures + S(m)])
+			}
+
+			likelihoods[n * numClasses + c] = total
+		}
+	}
+
+	return likelihoods, nil
+}
+
+// PredictLogProba returns an [N, C] tensor of log class probabilities whose
+// columns follow the order of Classes.
+func (model *MultinomialNB[T, S]) PredictLogProba(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	likelihoods, err := model.jointLogLikelihood(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return normalizeLogLikelihoods[T, S](likelihoods, S(len(model.Classes)))
+}
+
+func (model *MultinomialNB[T, S]) PredictProba(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	logProbabilities, err := model.PredictLogProba(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return expLogProbabilities(logProbabilities), nil
+}
+
+func (model *MultinomialNB[T, S]) Predict(features *Tensor[T, S]) ([]string, error) {
+	likelihoods, err := model.jointLogLikelihood(features)
+	if err != nil {
+		return nil, err
+	}
+
+	return mostLikelyClasses(likelihoods, model.Classes), nil
+}
+
+// normalizeLogLikelihoods turns unnormalized per-class log likelihoods into
+// log probabilities with the log-sum-exp trick so no row underflows to zero.
+func normalizeLogLikelihoods[T Numeric, S Index](likelihoods []float64, numClasses S) (*Tensor[T, S], error) {
+	numSamples := S(len(likelihoods)) / numClasses
+
+	result, err := InitTensor[T, S]([]S{numSamples, numClasses})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n := S(0); n < numSamples; n++ {
+		row := likelihoods[n * numClasses: (n + 1) * numClasses]
+
+		largest := math.Inf(-1)
+		for _, val := range row {
+			largest = math.Max(largest, val)
+		}
+
+		sum := 0.0
+		for _, val := range row {
+			sum += math.Exp(val - largest)
+		}
+		logNormalizer := largest + math.Log(sum)
+
+		for c, val := range row {
+			result.Data[n * numClasses + S(c)] = T(val - logNormalizer)
+		}
+	}
+
+	return result, nil
+}
+
+func expLogProbabilities[T Numeric, S Index](logProbabilities *Tensor[T, S]) *Tensor[T, S] {
+	for n, val := range logProbabilities.Data {
+		logProbabilities.Data[n] = T(math.Exp(float64(val)))
+	}
+
+	return logProbabilities
+}
+
+func mostLikelyClasses(likelihoods []float64, classes []string) []string {
+	numClasses := len(classes)
+	predictions := make([]string, len(likelihoods) / numClasses)
+
+	for n := range predictions {
+		row := likelihoods[n * numClasses: (n + 1) * numClasses]
+
+		best := 0
+		for c := range row {
+			if row[c] > row[best] {
+				best = c
+			}
+		}
+		predictions[n] = classes[best]
+	}
+
+	return predictions
+}
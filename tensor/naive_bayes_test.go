@@ -0,0 +1,216 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGaussianNBFit(t *testing.T) {
+	features, _ := InitTensor64(4, 1)
+	features.Data = []float64{1.0, 3.0, 10.0, 12.0}
+	labels := []string{"a", "a", "b", "b"}
+
+	model, err := InitGaussianNB[float64, uint64](0)
+	if err != nil {
+		t.Fatalf("InitGaussianNB failed: %v\n", err)
+	}
+
+	err = model.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("GaussianNB Fit failed: %v\n", err)
+	}
+
+	if model.Means.Data[0] != 2.0 || model.Means.Data[1] != 11.0 {
+		t.Errorf("Unexpected class means: %v", model.Means.Data)
+	}
+
+	if model.Variances.Data[0] != 1.0 || model.Variances.Data[1] != 1.0 {
+		t.Errorf("Unexpected class variances: %v", model.Variances.Data)
+	}
+
+	query, _ := InitTensor64(2, 1)
+	query.Data = []float64{6.5, 2.0}
+
+	probabilities, err := model.PredictProba(query)
+	if err != nil {
+		t.Fatalf("GaussianNB PredictProba failed: %v\n", err)
+	}
+
+	// the midpoint between two equal-variance classes is a coin flip
+	if math.Abs(probabilities.Data[0] - 0.5) > 1e-9 || math.Abs(probabilities.Data[1] - 0.5) > 1e-9 {
+		t.Errorf("Expected equal probabilities at the midpoint, got %v", probabilities.Data[:2])
+	}
+
+	expected := 1.0 / (1.0 + math.Exp(-40.5))
+	if math.Abs(probabilities.Data[2] - expected) > 1e-9 {
+		t.Errorf("Expected P(a) = %v, got %v", expected, probabilities.Data[2])
+	}
+}
+
+func TestGaussianNBPredict(t *testing.T) {
+	features, labels := threeClusterData()
+
+	model, _ := InitGaussianNB[float64, uint64](1e-9)
+	err := model.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("GaussianNB Fit failed: %v\n", err)
+	}
+
+	predictions, err := model.Predict(features)
+	if err != nil {
+		t.Fatalf("GaussianNB Predict failed: %v\n", err)
+	}
+
+	for n := range labels {
+		if predictions[n] != labels[n] {
+			t.Errorf("Unexpected predictions: got %v, expected %v", predictions, labels)
+			break
+		}
+	}
+
+	logProbabilities, err := model.PredictLogProba(features)
+	if err != nil {
+		t.Fatalf("GaussianNB PredictLogProba failed: %v\n", err)
+	}
+
+	for n := uint64(0); n < logProbabilities.Shape[0]; n++ {
+		total := 0.0
+		for c := uint64(0); c < logProbabilities.Shape[1]; c++ {
+			val := logProbabilities.Data[n * logProbabilities.Shape[1] + c]
+			if val > 0 || math.IsNaN(val) {
+				t.Fatalf("Invalid log probability %v", val)
+			}
+			total += math.Exp(val)
+		}
+
+		if math.Abs(total - 1.0) > 1e-9 {
+			t.Errorf("Row %v probabilities sum to %v", n, total)
+		}
+	}
+}
+
+func TestMultinomialNBFit(t *testing.T) {
+	// word counts for "free", "money", "meeting"
+	features, _ := InitTensor64(4, 3)
+	features.Data = []float64{
+		2.0, 0.0, 1.0,
+		1.0, 0.0, 0.0,
+		0.0, 1.0, 2.0,
+		0.0, 0.0, 3.0,
+	}
+	labels := []string{"spam", "spam", "ham", "ham"}
+
+	model, err := InitMultinomialNB[float64, uint64](1.0)
+	if err != nil {
+		t.Fatalf("InitMultinomialNB failed: %v\n", err)
+	}
+
+	err = model.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("MultinomialNB Fit failed: %v\n", err)
+	}
+
+	if model.Classes[0] != "ham" || model.Classes[1] != "spam" {
+		t.Fatalf("Unexpected classes: %v", model.Classes)
+	}
+
+	// ham counts are [0, 1, 5] and spam counts are [3, 0, 1], plus one each
+	expected := []float64{1.0 / 9.0, 2.0 / 9.0, 6.0 / 9.0, 4.0 / 7.0, 1.0 / 7.0, 2.0 / 7.0}
+	for n, val := range model.FeatureLogProb.Data {
+		if math.Abs(math.Exp(val) - expected[n]) > 1e-9 {
+			t.Errorf("Unexpected feature probability at %v: got %v, expected %v", n, math.Exp(val), expected[n])
+		}
+	}
+
+	query, _ := InitTensor64(2, 3)
+	query.Data = []float64{3.0, 0.0, 0.0, 0.0, 1.0, 4.0}
+
+	predictions, err := model.Predict(query)
+	if err != nil {
+		t.Fatalf("MultinomialNB Predict failed: %v\n", err)
+	}
+
+	if predictions[0] != "spam" || predictions[1] != "ham" {
+		t.Errorf("Unexpected predictions: %v", predictions)
+	}
+
+	probabilities, err := model.PredictProba(query)
+	if err != nil {
+		t.Fatalf("MultinomialNB PredictProba failed: %v\n", err)
+	}
+
+	spamScore := math.Pow(4.0 / 7.0, 3)
+	hamScore := math.Pow(1.0 / 9.0, 3)
+	expectedSpam := spamScore / (spamScore + hamScore)
+	if math.Abs(probabilities.Data[1] - expectedSpam) > 1e-9 {
+		t.Errorf("Expected P(spam) = %v, got %v", expectedSpam, probabilities.Data[1])
+	}
+}
+
+func TestNaiveBayesErrors(t *testing.T) {
+	_, err := InitMultinomialNB[float64, uint64](-1.0)
+	if err == nil {
+		t.Errorf("Expected an error for negative alpha")
+	}
+
+	features, _ := InitTensor64(2, 2)
+	features.Data = []float64{1.0, -1.0, 0.0, 2.0}
+
+	multinomial, _ := InitMultinomialNB[float64, uint64](1.0)
+	err = multinomial.Fit(features, []string{"a", "b"})
+	if err == nil {
+		t.Errorf("Expected an error for negative counts")
+	}
+
+	gaussian, _ := InitGaussianNB[float64, uint64](1e-9)
+	_, err = gaussian.Predict(features)
+	if err == nil {
+		t.Errorf("Expected an error when predicting before Fit")
+	}
+
+	err = gaussian.Fit(features, []string{"a"})
+	if err == nil {
+		t.Errorf("Expected an error for mismatched labels")
+	}
+}
+
+func TestGaussianNBConstantColumn(t *testing.T) {
+	// the second column is constant within each class, so its variance is
+	// only the smoothing term
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{1.0, 5.0, 3.0, 5.0, 10.0, 6.0, 12.0, 6.0}
+	labels := []string{"a", "a", "b", "b"}
+
+	model, _ := InitGaussianNB[float64, uint64](1e-2)
+	err := model.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("GaussianNB Fit failed: %v\n", err)
+	}
+
+	// the first column has the largest overall variance, 21.25
+	epsilon := 1e-2 * 21.25
+	if math.Abs(model.Variances.Data[0] - (1.0 + epsilon)) > 1e-12 || math.Abs(model.Variances.Data[1] - epsilon) > 1e-12 {
+		t.Errorf("Unexpected class a variances: %v", model.Variances.Data[:2])
+	}
+
+	query, _ := InitTensor64(1, 2)
+	query.Data = []float64{6.0, 5.5}
+	probabilities, _ := model.PredictProba(query)
+
+	// changing the units of every feature leaves the probabilities alone
+	scaled, _ := features.MulScalar(1000.0)
+	scaledQuery, _ := query.MulScalar(1000.0)
+	model.Fit(scaled, labels)
+	scaledProbabilities, _ := model.PredictProba(scaledQuery)
+
+	if math.Abs(probabilities.Data[0] - scaledProbabilities.Data[0]) > 1e-9 {
+		t.Errorf("Probabilities depend on units: %v != %v", probabilities.Data, scaledProbabilities.Data)
+	}
+
+	// without smoothing a constant column has no usable variance
+	unsmoothed, _ := InitGaussianNB[float64, uint64](0)
+	err = unsmoothed.Fit(features, labels)
+	if err == nil {
+		t.Errorf("Expected an error for a zero variance without smoothing")
+	}
+}
@@ -0,0 +1,238 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type PCA[T Numeric, S Index] struct {
+	NumComponents		S
+	VarianceTarget		T
+	Whiten			bool
+	Scaler			*StandardScaler[T, S]
+	Components		*Tensor[T, S]
+	SingularValues		[]T
+	ExplainedVariance	[]T
+	ExplainedVarianceRatio	[]T
+}
+
+// InitPCA keeps numComponents principal components when it is non-zero.
+// Otherwise varianceTarget, a fraction in (0, 1), keeps the fewest components
+// whose cumulative explained variance ratio reaches it. With both set to
+// zero every component is kept.
+func InitPCA[T Numeric, S Index](numComponents S, varianceTarget T, whiten bool) (*PCA[T, S], error) {
+	target := float64(varianceTarget)
+	if target < 0 || target >= 1 {
+		return &PCA[T, S]{}, fmt.Errorf("PCA variance target must be in [0, 1), got %v", varianceTarget)
+	}
+
+	if numComponents > 0 && target > 0 {
+		return &PCA[T, S]{}, errors.New("PCA accepts either a number of components or a variance target, not both")
+	}
+
+	model := &PCA[T, S] {
+		NumComponents:	numComponents,
+		VarianceTarget:	varianceTarget,
+		Whiten:		whiten,
+	}
+
+	return model, nil
+}
+
+func (pca *PCA[T, S]) centered(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(features.Shape) != 2 {
+		return &Tensor[T, S]{}, errors.New("PCA requires a 2D tensor")
+	}
+
+	numSamples := features.Shape[0]
+	numFeatures := features.Shape[1]
+
+	if S(len(pca.Scaler.Mu)) != numFeatures {
+		return &Tensor[T, S]{}, fmt.Errorf("Expecting %v features, got %v", len(pca.Scaler.Mu), numFeatures)
+	}
+
+	result, err := InitTensor[T, S]([]S{numSamples, numFeatures})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	rowStride := features.Strides[0]
+	colStride := features.Strides[1]
+
+	for n := S(0); n < numSamples; n++ {
+		for m := S(0); m < numFeatures; m++ {
+			result.Data[n * numFeatures + m] = features.Data[n * rowStride + m * colStride] - pca.Scaler.Mu[m]
+		}
+	}
+
+	return result, nil
+}
+
+func (pca *PCA[T, S]) Fit(features *Tensor[T, S]) error {
+	if len(features.Shape) != 2 {
+		return errors.New("PCA requires a 2D tensor")
+	}
+
+	numSamples := features.Shape[0]
+	numFeatures := features.Shape[1]
+
+	if numSamples < 2 {
+		return errors.New("PCA requires at least two samples")
+	}
+
+	pca.Scaler = &StandardScaler[T, S]{}
+	err := pca.Scaler.FitStatistics(features)
+	if err != nil {
+		return fmt.Errorf("FitStatistics failed during PCA Fit: %v", err)
+	}
+
+	centeredFeatures, err := pca.centered(features)
+	if err != nil {
+		return err
+	}
+
+	_, sigma, v, err := SVD(centeredFeatures)
+	if err != nil {
+		return fmt.Errorf("SVD failed during PCA Fit: %v", err)
+	}
+
+	maxComponents := sigma.Shape[0]
+
+	variances := make([]float64, maxComponents)
+	totalVariance := 0.0
+	for n := S(0); n < maxComponents; n++ {
+		s := float64(sigma.Data[n])
+		variances[n] = s * s / float64(numSamples - 1)
+		totalVariance += variances[n]
+	}
+
+	numComponents := maxComponents
+	if pca.NumComponents > 0 {
+		if pca.NumComponents > maxComponents {
+			return fmt.Errorf("PCA cannot keep %v components from data of rank at most %v", pca.NumComponents, maxComponents)
+		}
+		numComponents = pca.NumComponents
+	} else if target := float64(pca.VarianceTarget); target > 0 && totalVariance > 0 {
+		cumulative := 0.0
+		for n := S(0); n < maxComponents; n++ {
+			cumulative += variances[n] / totalVariance
+			if cumulative >= target {
+				numComponents = n + 1
+				break
+			}
+		}
+	}
+
+	components, err := InitTensor[T, S]([]S{numComponents, numFeatures})
+	if err != nil {
+		return err
+	}
+
+	pca.SingularValues = make([]T, numComponents)
+	pca.ExplainedVariance = make([]T, numComponents)
+	pca.ExplainedVarianceRatio = make([]T, numComponents)
+
+	for c := S(0); c < numComponents; c++ {
+		// flip signs so the largest loading of every component is positive,
+		// which makes the result independent of the SVD's sign choices
+		largest := 0.0
+		sign := 1.0
+		for f := S(0); f < numFeatures; f++ {
+			val := float64(v.Data[f * maxComponents + c])
+			if math.Abs(val) > largest {
+				largest = math.Abs(val)
+				sign = math.Copysign(1.0, val)
+			}
+		}
+
+		for f := S(0); f < numFeatures; f++ {
+			components.Data[c * numFeatures + f] = T(sign * float64(v.Data[f * maxComponents + c]))
+		}
+
+		pca.SingularValues[c] = sigma.Data[c]
+		pca.ExplainedVariance[c] = T(variances[c])
+		if totalVariance > 0 {
+			pca.ExplainedVarianceRatio[c] = T(variances[c] / totalVariance)
+		}
+	}
+
+	pca.Components = components
+
+	return nil
+}
+
+func (pca *PCA[T, S]) Transform(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if pca.Components == nil || pca.Scaler == nil {
+		return &Tensor[T, S]{}, errors.New("PCA must be fitted (call Fit)")
+	}
+
+	centeredFeatures, err := pca.centered(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	componentsT, err := pca.Components.Transpose()
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	projected, err := centeredFeatures.Dot(componentsT)
+	if err != nil {
+		return &Tensor[T, S]{}, fmt.Errorf("Projection failed during PCA Transform: %v", err)
+	}
+
+	if pca.Whiten {
+		numComponents := projected.Shape[1]
+		for n := range projected.Data {
+			variance := float64(pca.ExplainedVariance[S(n) % numComponents])
+			if variance > 0 {
+				projected.Data[n] = T(float64(projected.Data[n]) / math.Sqrt(variance))
+			}
+		}
+	}
+
+	return projected, nil
+}
+
+func (pca *PCA[T, S]) InverseTransform(projected *Tensor[T, S]) (*Tensor[T, S], error) {
+	if pca.Components == nil || pca.Scaler == nil {
+		return &Tensor[T, S]{}, errors.New("PCA must be fitted (call Fit)")
+	}
+
+	if len(projected.Shape) != 2 || projected.Shape[1] != pca.Components.Shape[0] {
+		return &Tensor[T, S]{}, fmt.Errorf("InverseTransform expects shape [N, %v], got %v", pca.Components.Shape[0], projected.Shape)
+	}
+
+	input, err := projected.Contiguous()
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	if pca.Whiten {
+		unwhitened, err := InitTensor[T, S](input.Shape)
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+
+		numComponents := input.Shape[1]
+		for n := range input.Data {
+			variance := float64(pca.ExplainedVariance[S(n) % numComponents])
+			unwhitened.Data[n] = T(float64(input.Data[n]) * math.Sqrt(variance))
+		}
+
+		input = unwhitened
+	}
+
+	reconstructed, err := input.Dot(pca.Components)
+	if err != nil {
+		return &Tensor[T, S]{}, fmt.Errorf("Reconstruction failed during PCA InverseTransform: %v", err)
+	}
+
+	numFeatures := reconstructed.Shape[1]
+	for n := range reconstructed.Data {
+		reconstructed.Data[n] += pca.Scaler.Mu[S(n) % numFeatures]
+	}
+
+	return reconstructed, nil
+}
@@ -0,0 +1,141 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPCAExplainedVariance(t *testing.T) {
+	tol := 1e-9
+
+	// points on the line y = x with a small orthogonal offset
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{1.0, 1.0, 2.0, 2.0, 3.0, 3.0, 4.0, 4.2}
+
+	pca, err := InitPCA[float64, uint64](0, 0.0, false)
+	if err != nil {
+		t.Fatalf("InitPCA failed: %v\n", err)
+	}
+
+	err = pca.Fit(features)
+	if err != nil {
+		t.Fatalf("PCA Fit failed: %v\n", err)
+	}
+
+	if pca.Components.Shape[0] != 2 || pca.Components.Shape[1] != 2 {
+		t.Errorf("Unexpected shape of components: %v", pca.Components.Shape)
+	}
+
+	ratioSum := 0.0
+	for _, ratio := range pca.ExplainedVarianceRatio {
+		ratioSum += ratio
+	}
+
+	if math.Abs(ratioSum - 1.0) > tol {
+		t.Errorf("Explained variance ratios do not sum to 1: %v", pca.ExplainedVarianceRatio)
+	}
+
+	if pca.ExplainedVarianceRatio[0] < 0.99 {
+		t.Errorf("First component should explain almost all variance: %v", pca.ExplainedVarianceRatio)
+	}
+
+	if pca.Components.Data[0] < 0.6 || pca.Components.Data[1] < 0.6 {
+		t.Errorf("First component should point along y = x: %v", pca.Components.Data[:2])
+	}
+}
+
+func TestPCAVarianceTarget(t *testing.T) {
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{1.0, 1.0, 2.0, 2.0, 3.0, 3.0, 4.0, 4.2}
+
+	pca, _ := InitPCA[float64, uint64](0, 0.95, false)
+
+	err := pca.Fit(features)
+	if err != nil {
+		t.Fatalf("PCA Fit failed: %v\n", err)
+	}
+
+	if pca.Components.Shape[0] != 1 {
+		t.Errorf("Variance target should keep a single component, kept %v", pca.Components.Shape[0])
+	}
+
+	projected, err := pca.Transform(features)
+	if err != nil {
+		t.Fatalf("PCA Transform failed: %v\n", err)
+	}
+
+	if projected.Shape[0] != 4 || projected.Shape[1] != 1 {
+		t.Errorf("Unexpected projected shape: %v", projected.Shape)
+	}
+}
+
+func TestPCAInverseTransform(t *testing.T) {
+	tol := 1e-9
+
+	features, _ := InitRandomTensor64(10.0, 20, 3)
+
+	for _, whiten := range []bool{false, true} {
+		pca, _ := InitPCA[float64, uint64](3, 0.0, whiten)
+
+		err := pca.Fit(features)
+		if err != nil {
+			t.Fatalf("PCA Fit failed: %v\n", err)
+		}
+
+		projected, err := pca.Transform(features)
+		if err != nil {
+			t.Fatalf("PCA Transform failed: %v\n", err)
+		}
+
+		reconstructed, err := pca.InverseTransform(projected)
+		if err != nil {
+			t.Fatalf("PCA InverseTransform failed: %v\n", err)
+		}
+
+		for n := range features.Data {
+			if math.Abs(reconstructed.Data[n] - features.Data[n]) > tol {
+				t.Errorf("Full rank PCA round trip failed (whiten=%v) at %v: %v != %v", whiten, n, reconstructed.Data[n], features.Data[n])
+				break
+			}
+		}
+	}
+}
+
+func TestPCAWhiten(t *testing.T) {
+	tol := 1e-9
+
+	features, _ := InitRandomTensor64(10.0, 50, 3)
+
+	pca, _ := InitPCA[float64, uint64](2, 0.0, true)
+	pca.Fit(features)
+
+	projected, err := pca.Transform(features)
+	if err != nil {
+		t.Fatalf("PCA Transform failed: %v\n", err)
+	}
+
+	for c := uint64(0); c < 2; c++ {
+		sumOfSquares := 0.0
+		for n := uint64(0); n < 50; n++ {
+			val := projected.Data[n * 2 + c]
+			sumOfSquares += val * val
+		}
+
+		variance := sumOfSquares / 49.0
+		if math.Abs(variance - 1.0) > tol {
+			t.Errorf("Whitened component %v does not have unit variance: %v", c, variance)
+		}
+	}
+}
+
+func TestInitPCAValidation(t *testing.T) {
+	_, err := InitPCA[float64, uint64](2, 0.9, false)
+	if err == nil {
+		t.Errorf("Both component count and variance target accepted by InitPCA")
+	}
+
+	_, err = InitPCA[float64, uint64](0, 1.5, false)
+	if err == nil {
+		t.Errorf("Variance target outside [0, 1) accepted by InitPCA")
+	}
+}
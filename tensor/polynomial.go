@@ -0,0 +1,173 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PolynomialFeatures expands every sample into all monomials of its features
+// up to Degree, so linear models can fit polynomial relationships. Outputs are
+// ordered by degree, then lexicographically by feature index: for two features
+// and degree 2 they are [1,] x0, x1, x0^2, x0 x1, x1^2.
+//
+// InteractionOnly keeps only products of distinct features (x0 x1 but not
+// x0^2). IncludeBias adds a leading column of ones, laid out like the column
+// from AugmentBias.
+type PolynomialFeatures[T Numeric, S Index] struct {
+	Degree			int
+	InteractionOnly		bool
+	IncludeBias		bool
+	NumInputFeatures	int
+	Powers			[][]int
+}
+
+func InitPolynomialFeatures[T Numeric, S Index](degree int, interactionOnly bool, includeBias bool) (*PolynomialFeatures[T, S], error) {
+	if degree < 1 {
+		return &PolynomialFeatures[T, S]{}, fmt.Errorf("PolynomialFeatures degree must be at least 1, got %v", degree)
+	}
+
+	model := &PolynomialFeatures[T, S] {
+		Degree:			degree,
+		InteractionOnly:	interactionOnly,
+		IncludeBias:		includeBias,
+	}
+
+	return model, nil
+}
+
+// combinations appends every non-decreasing sequence of length degree drawn
+// from the feature indices starting at start, or every strictly increasing one
+// when distinct is set.
+func combinations(numFeatures, degree, start int, distinct bool, prefix []int, result [][]int) [][]int {
+	if len(prefix) == degree {
+		return append(result, append([]int(nil), prefix...))
+	}
+
+	for m := start; m < numFeatures; m++ {
+		next := m
+		if distinct {
+			next = m + 1
+		}
+		result = combinations(numFeatures, degree, next, distinct, append(prefix, m), result)
+	}
+
+	return result
+}
+
+// Fit enumerates the output monomials for the number of input features.
+func (poly *PolynomialFeatures[T, S]) Fit(features *Tensor[T, S]) error {
+	if poly.Degree < 1 {
+		return fmt.Errorf("PolynomialFeatures degree must be at least 1, got %v", poly.Degree)
+	}
+
+	if len(features.Shape) != 2 {
+		return errors.New("PolynomialFeatures requires a 2D tensor")
+	}
+
+	numFeatures := int(features.Shape[1])
+
+	poly.NumInputFeatures = numFeatures
+	poly.Powers = make([][]int, 0)
+
+	if poly.IncludeBias {
+		poly.Powers = append(poly.Powers, make([]int, numFeatures))
+	}
+
+	for degree := 1; degree <= poly.Degree; degree++ {
+		for _, combination := range combinations(numFeatures, degree, 0, poly.InteractionOnly, nil, nil) {
+			powers := make([]int, numFeatures)
+			for _, m := range combination {
+				powers[m]++
+			}
+			poly.Powers = append(poly.Powers, powers)
+		}
+	}
+
+	return nil
+}
+
+// NumOutputFeatures is the number of columns Transform produces.
+func (poly *PolynomialFeatures[T, S]) NumOutputFeatures() int {
+	return len(poly.Powers)
+}
+
+// Transform returns the [N, NumOutputFeatures()] tensor of monomials.
+func (poly *PolynomialFeatures[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	if poly.Powers == nil {
+		return &Tensor[T, S]{}, errors.New("PolynomialFeatures must be fitted (call Fit)")
+	}
+
+	if len(input.Shape) != 2 || int(input.Shape[1]) != poly.NumInputFeatures {
+		return &Tensor[T, S]{}, fmt.Errorf("Expecting a [N, %v] tensor, got shape %v", poly.NumInputFeatures, input.Shape)
+	}
+
+	numSamples := input.Shape[0]
+	numOutputs := S(len(poly.Powers))
+
+	result, err := InitTensor[T, S]([]S{numSamples, numOutputs})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	rowStride := input.Strides[0]
+	colStride := input.Strides[1]
+
+	for n := S(0); n < numSamples; n++ {
+		for j, powers := range poly.Powers {
+			product := T(1.0)
+			for m, power := range powers {
+				val := input.Data[n * rowStride + S(m) * colStride]
+				for p := 0; p < power; p++ {
+					product *= val
+				}
+			}
+
+			result.Data[n * numOutputs + S(j)] = product
+		}
+	}
+
+	return result, nil
+}
+
+// FeatureNames describes every output column, such as "x0^2" or "x0 x1", in
+// the same order as Transform. inputNames replaces the default x0, x1, ...;
+// pass nil to use the defaults. The bias column is named "1".
+func (poly *PolynomialFeatures[T, S]) FeatureNames(inputNames []string) ([]string, error) {
+	if poly.Powers == nil {
+		return nil, errors.New("PolynomialFeatures must be fitted (call Fit)")
+	}
+
+	if inputNames == nil {
+		inputNames = make([]string, poly.NumInputFeatures)
+		for m := range inputNames {
+			inputNames[m] = fmt.Sprintf("x%v", m)
+		}
+	}
+
+	if len(inputNames) != poly.NumInputFeatures {
+		return nil, fmt.Errorf("Expecting %v input names, got %v", poly.NumInputFeatures, len(inputNames))
+	}
+
+	names := make([]string, len(poly.Powers))
+	for j, powers := range poly.Powers {
+		terms := make([]string, 0)
+		for m, power := range powers {
+			switch {
+			case power == 1:
+				terms = append(terms, inputNames[m])
+			case power > 1:
+				terms = append(terms, fmt.Sprintf("%v^%v", inputNames[m], power))
+			}
+		}
+
+		if len(terms) == 0 {
+			names[j] = "1"
+			continue
+		}
+
+		names[j] = strings.Join(terms, " ")
+	}
+
+	return names, nil
+}
@@ -0,0 +1,119 @@
+package tensor
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestPolynomialFeatures(t *testing.T) {
+	input, _ := InitTensor64(2, 2)
+	input.Data = []float64{2.0, 3.0, -1.0, 4.0}
+
+	poly, err := InitPolynomialFeatures[float64, uint64](2, false, true)
+	if err != nil {
+		t.Fatalf("InitPolynomialFeatures failed: %v", err)
+	}
+
+	expanded, err := FitTransform[float64, uint64](poly, input)
+	if err != nil {
+		t.Fatalf("PolynomialFeatures failed: %v", err)
+	}
+
+	if expanded.Shape[0] != 2 || expanded.Shape[1] != 6 || poly.NumOutputFeatures() != 6 {
+		t.Fatalf("Expected a [2, 6] tensor, got %v", expanded.Shape)
+	}
+
+	expected := []float64 {
+		1.0, 2.0, 3.0, 4.0, 6.0, 9.0,
+		1.0, -1.0, 4.0, 1.0, -4.0, 16.0,
+	}
+	if !reflect.DeepEqual(expanded.Data, expected) {
+		t.Errorf("Unexpected expansion: %v", expanded.Data)
+	}
+
+	names, _ := poly.FeatureNames(nil)
+	if !reflect.DeepEqual(names, []string{"1", "x0", "x1", "x0^2", "x0 x1", "x1^2"}) {
+		t.Errorf("Unexpected feature names: %v", names)
+	}
+
+	names, _ = poly.FeatureNames([]string{"age", "height"})
+	if names[4] != "age height" {
+		t.Errorf("Unexpected custom feature name: %v", names[4])
+	}
+
+	_, err = poly.FeatureNames([]string{"age"})
+	if err == nil {
+		t.Errorf("Expected an error for the wrong number of input names")
+	}
+
+	wrongShape, _ := InitTensor64(2, 3)
+	_, err = poly.Transform(wrongShape)
+	if err == nil {
+		t.Errorf("Expected an error for the wrong number of features")
+	}
+
+	_, err = InitPolynomialFeatures[float64, uint64](0, false, false)
+	if err == nil {
+		t.Errorf("Expected an error for degree 0")
+	}
+}
+
+func TestPolynomialInteractionOnly(t *testing.T) {
+	input, _ := InitTensor64(1, 3)
+	input.Data = []float64{2.0, 3.0, 5.0}
+
+	poly, _ := InitPolynomialFeatures[float64, uint64](3, true, false)
+	expanded, err := FitTransform[float64, uint64](poly, input)
+	if err != nil {
+		t.Fatalf("PolynomialFeatures failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(expanded.Data, []float64{2.0, 3.0, 5.0, 6.0, 10.0, 15.0, 30.0}) {
+		t.Errorf("Unexpected interaction features: %v", expanded.Data)
+	}
+
+	names, _ := poly.FeatureNames(nil)
+	if !reflect.DeepEqual(names, []string{"x0", "x1", "x2", "x0 x1", "x0 x2", "x1 x2", "x0 x1 x2"}) {
+		t.Errorf("Unexpected interaction names: %v", names)
+	}
+}
+
+func TestPolynomialRegression(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+
+	numSamples := uint(200)
+	xBase, _ := InitTensor[float64, uint]([]uint{numSamples, 2})
+	y, _ := InitTensor[float64, uint]([]uint{numSamples, 1})
+
+	for n := uint(0); n < numSamples; n++ {
+		x0 := r.Float64() * 4.0 - 2.0
+		x1 := r.Float64() * 4.0 - 2.0
+		xBase.Data[n * 2] = x0
+		xBase.Data[n * 2 + 1] = x1
+		y.Data[n] = 1.0 + 2.0 * x0 - 3.0 * x0 * x1 + 0.5 * x1 * x1
+	}
+
+	// with IncludeBias the expansion takes the place of AugmentBias
+	poly, _ := InitPolynomialFeatures[float64, uint](2, false, true)
+	X, err := FitTransform[float64, uint](poly, xBase)
+	if err != nil {
+		t.Fatalf("PolynomialFeatures failed: %v", err)
+	}
+
+	lrm, _ := InitLinearRegressionModel[float64, uint](X.Shape[1], 0.0, 0.0, 0.0, 1000)
+	err = lrm.FitCoordinateDescent(X, y)
+	if err != nil {
+		t.Fatalf("FitCoordinateDescent failed: %v", err)
+	}
+
+	// 1, x0, x1, x0^2, x0 x1, x1^2
+	expected := []float64{1.0, 2.0, 0.0, 0.0, -3.0, 0.5}
+	names, _ := poly.FeatureNames(nil)
+	for m, weight := range lrm.Weights.Data {
+		if math.Abs(weight - expected[m]) > 1e-6 {
+			t.Errorf("Unexpected weight for %v: got %v, expected %v", names[m], weight, expected[m])
+		}
+	}
+}
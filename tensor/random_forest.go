@@ -0,0 +1,450 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+type forestSettings struct {
+	numTrees	int
+	bootstrap	bool
+	numWorkers	int
+	seed		int64
+}
+
+type forestSample struct {
+	samples		[]int
+	inBag		[]bool
+	seed		int64
+}
+
+// drawForestSamples picks the training rows and a tree seed for every tree up
+// front, so the result does not depend on how the trees are scheduled.
+func drawForestSamples(settings forestSettings, numSamples int) []forestSample {
+	r := newSeededRand(settings.seed)
+
+	draws := make([]forestSample, settings.numTrees)
+	for n := range draws {
+		draws[n].seed = r.Int63() + 1
+		draws[n].inBag = make([]bool, numSamples)
+
+		if !settings.bootstrap {
+			draws[n].samples = allSamples(numSamples)
+			for m := range draws[n].inBag {
+				draws[n].inBag[m] = true
+			}
+			continue
+		}
+
+		draws[n].samples = make([]int, numSamples)
+		for m := range draws[n].samples {
+			sample := r.Intn(numSamples)
+			draws[n].samples[m] = sample
+			draws[n].inBag[sample] = true
+		}
+	}
+
+	return draws
+}
+
+// trainForest runs train for every tree index on a pool of numWorkers
+// goroutines and returns the first error encountered.
+func trainForest(numTrees int, numWorkers int, train func(n int) error) error {
+	numWorkers = max(1, min(numWorkers, numTrees))
+
+	jobs := make(chan int)
+	errs := make(chan error, numTrees)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < numWorkers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range jobs {
+				err := train(n)
+				if err != nil {
+					errs <- fmt.Errorf("Training tree %v failed: %v", n, err)
+				}
+			}
+		}()
+	}
+
+	for n := 0; n < numTrees; n++ {
+		jobs <- n
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		return err
+	}
+
+	return nil
+}
+
+func averageImportances[T Numeric](perTree [][]T, numFeatures int) []T {
+	sums := make([]float64, numFeatures)
+	for _, importances := range perTree {
+		for n, val := range importances {
+			sums[n] += float64(val)
+		}
+	}
+
+	return normalizedImportances[T](sums)
+}
+
+type RandomForestClassifier[T Numeric, S Index] struct {
+	NumTrees		S
+	Criterion		SplitCriterion
+	MaxDepth		S
+	MinSamplesLeaf		S
+	MaxFeatures		S
+	Bootstrap		bool
+	NumWorkers		int
+	Seed			int64
+	Classes			[]string
+	Trees			[]*DecisionTreeClassifier[T, S]
+	FeatureImportances	[]T
+	OOBScore		T
+}
+
+// InitRandomForestClassifier builds an ensemble of numTrees bootstrapped
+// classification trees. A maxFeatures of 0 considers sqrt(numFeatures)
+// random features at every split.
+func InitRandomForestClassifier[T Numeric, S Index](
+	numTrees S,
+	maxDepth S,
+	maxFeatures S) (*RandomForestClassifier[T, S], error) {
+
+	if numTrees == 0 {
+		return &RandomForestClassifier[T, S]{}, errors.New("RandomForest requires at least one tree")
+	}
+
+	model := &RandomForestClassifier[T, S] {
+		NumTrees:	numTrees,
+		Criterion:	GiniCriterion,
+		MaxDepth:	maxDepth,
+		MinSamplesLeaf:	1,
+		MaxFeatures:	maxFeatures,
+		Bootstrap:	true,
+		NumWorkers:	runtime.NumCPU(),
+	}
+
+	return model, nil
+}
+
+func (forest *RandomForestClassifier[T, S]) Fit(features *Tensor[T, S], labels []string) error {
+	if len(features.Shape) != 2 {
+		return errors.New("RandomForest requires a 2D feature tensor")
+	}
+
+	numSamples := int(features.Shape[0])
+	numFeatures := int(features.Shape[1])
+
+	if len(labels) != numSamples {
+		return fmt.Errorf("Expecting %v labels, got %v", numSamples, len(labels))
+	}
+
+	classes, encoded := encodeLabels(labels)
+
+	maxFeatures := forest.MaxFeatures
+	if maxFeatures == 0 {
+		maxFeatures = S(max(1, int(math.Sqrt(float64(numFeatures)))))
+	}
+
+	settings := forestSettings{int(forest.NumTrees), forest.Bootstrap, forest.NumWorkers, forest.Seed}
+	draws := drawForestSamples(settings, numSamples)
+
+	trees := make([]*DecisionTreeClassifier[T, S], len(draws))
+	err := trainForest(len(draws), forest.NumWorkers, func(n int) error {
+		tree := &DecisionTreeClassifier[T, S] {
+			Criterion:	forest.Criterion,
+			MaxDepth:	forest.MaxDepth,
+			MinSamplesLeaf:	forest.MinSamplesLeaf,
+			MaxFeatures:	maxFeatures,
+			Seed:		draws[n].seed,
+		}
+
+		trees[n] = tree
+		return tree.fitEncoded(features, classes, encoded, draws[n].samples)
+	})
+	if err != nil {
+		return err
+	}
+
+	forest.Classes = classes
+	forest.Trees = trees
+
+	perTree := make([][]T, len(trees))
+	for n, tree := range trees {
+		perTree[n] = tree.FeatureImportances
+	}
+	forest.FeatureImportances = averageImportances(perTree, numFeatures)
+
+	rows, err := matrixToFloat64(features)
+	if err != nil {
+		return err
+	}
+
+	// out-of-bag accuracy: each sample is scored only by the trees that
+	// never saw it during training
+	correct := 0
+	scored := 0
+	votes := make([]float64, len(classes))
+	for m, row := range rows {
+		for c := range votes {
+			votes[c] = 0
+		}
+
+		numVoters := 0
+		for n, tree := range trees {
+			if draws[n].inBag[m] {
+				continue
+			}
+
+			for c, p := range tree.Root.find(row).Distribution {
+				votes[c] += p
+			}
+			numVoters++
+		}
+
+		if numVoters == 0 {
+			continue
+		}
+
+		best := 0
+		for c := range votes {
+			if votes[c] > votes[best] {
+				best = c
+			}
+		}
+
+		scored++
+		if best == encoded[m] {
+			correct++
+		}
+	}
+
+	forest.OOBScore = T(0)
+	if scored > 0 {
+		forest.OOBScore = T(float64(correct) / float64(scored))
+	}
+
+	return nil
+}
+
+func (forest *RandomForestClassifier[T, S]) PredictProba(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(forest.Trees) == 0 {
+		return &Tensor[T, S]{}, errors.New("RandomForestClassifier must be fitted (call Fit)")
+	}
+
+	if len(features.Shape) != 2 {
+		return &Tensor[T, S]{}, errors.New("RandomForest requires a 2D feature tensor")
+	}
+
+	numClasses := S(len(forest.Classes))
+	result, err := InitTensor[T, S]([]S{features.Shape[0], numClasses})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	sums := make([]float64, len(result.Data))
+	for _, tree := range forest.Trees {
+		probabilities, err := tree.PredictProba(features)
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+
+		for n, p := range probabilities.Data {
+			sums[n] += float64(p)
+		}
+	}
+
+	for n, sum := range sums {
+		result.Data[n] = T(sum / float64(len(forest.Trees)))
+	}
+
+	return result, nil
+}
+
+func (forest *RandomForestClassifier[T, S]) Predict(features *Tensor[T, S]) ([]string, error) {
+	probabilities, err := forest.PredictProba(features)
+	if err != nil {
+		return nil, err
+	}
+
+	numSamples := probabilities.Shape[0]
+	numClasses := probabilities.Shape[1]
+
+	predictions := make([]string, numSamples)
+	for n := S(0); n < numSamples; n++ {
+		best := S(0)
+		for c := S(1); c < numClasses; c++ {
+			if probabilities.Data[n * numClasses + c] > probabilities.Data[n * numClasses + best] {
+				best = c
+			}
+		}
+		predictions[n] = forest.Classes[best]
+	}
+
+	return predictions, nil
+}
+
+type RandomForestRegressor[T Numeric, S Index] struct {
+	NumTrees		S
+	MaxDepth		S
+	MinSamplesLeaf		S
+	MaxFeatures		S
+	Bootstrap		bool
+	NumWorkers		int
+	Seed			int64
+	Trees			[]*DecisionTreeRegressor[T, S]
+	FeatureImportances	[]T
+	OOBScore		T
+}
+
+// InitRandomForestRegressor builds an ensemble of numTrees bootstrapped
+// regression trees. A maxFeatures of 0 considers every feature at each split.
+func InitRandomForestRegressor[T Numeric, S Index](
+	numTrees S,
+	maxDepth S,
+	maxFeatures S) (*RandomForestRegressor[T, S], error) {
+
+	if numTrees == 0 {
+		return &RandomForestRegressor[T, S]{}, errors.New("RandomForest requires at least one tree")
+	}
+
+	model := &RandomForestRegressor[T, S] {
+		NumTrees:	numTrees,
+		MaxDepth:	maxDepth,
+		MinSamplesLeaf:	1,
+		MaxFeatures:	maxFeatures,
+		Bootstrap:	true,
+		NumWorkers:	runtime.NumCPU(),
+	}
+
+	return model, nil
+}
+
+func (forest *RandomForestRegressor[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	if len(features.Shape) != 2 {
+		return errors.New("RandomForest requires a 2D feature tensor")
+	}
+
+	numSamples := int(features.Shape[0])
+	numFeatures := int(features.Shape[1])
+
+	if len(targets.Data) != numSamples {
+		return fmt.Errorf("Expecting %v targets, got %v", numSamples, len(targets.Data))
+	}
+
+	y := make([]float64, numSamples)
+	for n := range y {
+		y[n] = float64(targets.Data[n])
+	}
+
+	settings := forestSettings{int(forest.NumTrees), forest.Bootstrap, forest.NumWorkers, forest.Seed}
+	draws := drawForestSamples(settings, numSamples)
+
+	trees := make([]*DecisionTreeRegressor[T, S], len(draws))
+	err := trainForest(len(draws), forest.NumWorkers, func(n int) error {
+		tree := &DecisionTreeRegressor[T, S] {
+			MaxDepth:	forest.MaxDepth,
+			MinSamplesLeaf:	forest.MinSamplesLeaf,
+			MaxFeatures:	forest.MaxFeatures,
+			Seed:		draws[n].seed,
+		}
+
+		trees[n] = tree
+		return tree.fitTargets(features, y, draws[n].samples)
+	})
+	if err != nil {
+		return err
+	}
+
+	forest.Trees = trees
+
+	perTree := make([][]T, len(trees))
+	for n, tree := range trees {
+		perTree[n] = tree.FeatureImportances
+	}
+	forest.FeatureImportances = averageImportances(perTree, numFeatures)
+
+	rows, err := matrixToFloat64(features)
+	if err != nil {
+		return err
+	}
+
+	// out-of-bag R2 over the samples left out by at least one tree
+	oobPredictions := make([]T, 0, numSamples)
+	oobTargets := make([]T, 0, numSamples)
+	for m, row := range rows {
+		sum := 0.0
+		numVoters := 0
+		for n, tree := range trees {
+			if draws[n].inBag[m] {
+				continue
+			}
+
+			sum += tree.Root.find(row).Value
+			numVoters++
+		}
+
+		if numVoters == 0 {
+			continue
+		}
+
+		oobPredictions = append(oobPredictions, T(sum / float64(numVoters)))
+		oobTargets = append(oobTargets, targets.Data[m])
+	}
+
+	forest.OOBScore = T(0)
+	if len(oobTargets) > 0 {
+		predicted := &Tensor[T, S]{Shape: []S{S(len(oobTargets))}, Strides: []S{1}, Data: oobPredictions}
+		actual := &Tensor[T, S]{Shape: []S{S(len(oobTargets))}, Strides: []S{1}, Data: oobTargets}
+
+		forest.OOBScore, err = R2Score(predicted, actual)
+		if err != nil {
+			return fmt.Errorf("Out-of-bag R2 failed: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (forest *RandomForestRegressor[T, S]) Predict(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(forest.Trees) == 0 {
+		return &Tensor[T, S]{}, errors.New("RandomForestRegressor must be fitted (call Fit)")
+	}
+
+	if len(features.Shape) != 2 {
+		return &Tensor[T, S]{}, errors.New("RandomForest requires a 2D feature tensor")
+	}
+
+	result, err := InitTensor[T, S]([]S{features.Shape[0], 1})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	sums := make([]float64, len(result.Data))
+	for _, tree := range forest.Trees {
+		predictions, err := tree.Predict(features)
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+
+		for n, val := range predictions.Data {
+			sums[n] += float64(val)
+		}
+	}
+
+	for n, sum := range sums {
+		result.Data[n] = T(sum / float64(len(forest.Trees)))
+	}
+
+	return result, nil
+}
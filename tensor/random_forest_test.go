@@ -0,0 +1,168 @@
+package tensor
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func forestRegressionData() (*Tensor[float64, uint64], *Tensor[float64, uint64]) {
+	r := rand.New(rand.NewSource(7))
+
+	features, _ := InitTensor64(200, 2)
+	targets, _ := InitTensor64(200, 1)
+
+	for n := 0; n < 200; n++ {
+		x := r.Float64() * 6.0 - 3.0
+		noise := r.Float64() * 6.0 - 3.0
+		features.Data[n * 2] = x
+		features.Data[n * 2 + 1] = noise
+		targets.Data[n] = math.Sin(x)
+	}
+
+	return features, targets
+}
+
+func TestRandomForestClassifierFit(t *testing.T) {
+	features, labels := threeClusterData()
+
+	forest, err := InitRandomForestClassifier[float64, uint64](25, 0, 0)
+	if err != nil {
+		t.Fatalf("InitRandomForestClassifier failed: %v\n", err)
+	}
+	forest.Seed = 3
+
+	err = forest.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("RandomForestClassifier Fit failed: %v\n", err)
+	}
+
+	if len(forest.Trees) != 25 {
+		t.Errorf("Expected 25 trees, got %v", len(forest.Trees))
+	}
+
+	predictions, err := forest.Predict(features)
+	if err != nil {
+		t.Fatalf("RandomForestClassifier Predict failed: %v\n", err)
+	}
+
+	for n := range labels {
+		if predictions[n] != labels[n] {
+			t.Errorf("Unexpected predictions: got %v, expected %v", predictions, labels)
+			break
+		}
+	}
+
+	probabilities, err := forest.PredictProba(features)
+	if err != nil {
+		t.Fatalf("RandomForestClassifier PredictProba failed: %v\n", err)
+	}
+
+	for n := uint64(0); n < probabilities.Shape[0]; n++ {
+		total := 0.0
+		for c := uint64(0); c < probabilities.Shape[1]; c++ {
+			total += probabilities.Data[n * probabilities.Shape[1] + c]
+		}
+
+		if math.Abs(total - 1.0) > 1e-9 {
+			t.Errorf("Row %v probabilities sum to %v", n, total)
+		}
+	}
+
+	if forest.OOBScore < 0.9 {
+		t.Errorf("Expected a high out-of-bag score on separable data, got %v", forest.OOBScore)
+	}
+
+	importanceSum := forest.FeatureImportances[0] + forest.FeatureImportances[1]
+	if math.Abs(importanceSum - 1.0) > 1e-9 {
+		t.Errorf("Feature importances should sum to 1, got %v", forest.FeatureImportances)
+	}
+}
+
+func TestRandomForestSeededDeterminism(t *testing.T) {
+	features, targets := forestRegressionData()
+
+	var predictions [2]*Tensor[float64, uint64]
+	for run := range predictions {
+		forest, err := InitRandomForestRegressor[float64, uint64](10, 6, 1)
+		if err != nil {
+			t.Fatalf("InitRandomForestRegressor failed: %v\n", err)
+		}
+		forest.Seed = 11
+		forest.NumWorkers = run + 1
+
+		err = forest.Fit(features, targets)
+		if err != nil {
+			t.Fatalf("RandomForestRegressor Fit failed: %v\n", err)
+		}
+
+		predictions[run], err = forest.Predict(features)
+		if err != nil {
+			t.Fatalf("RandomForestRegressor Predict failed: %v\n", err)
+		}
+	}
+
+	for n := range predictions[0].Data {
+		if predictions[0].Data[n] != predictions[1].Data[n] {
+			t.Fatalf("Seeded forests differ at row %v: %v vs %v", n, predictions[0].Data[n], predictions[1].Data[n])
+		}
+	}
+}
+
+func TestRandomForestRegressorFit(t *testing.T) {
+	features, targets := forestRegressionData()
+
+	forest, err := InitRandomForestRegressor[float64, uint64](30, 0, 2)
+	if err != nil {
+		t.Fatalf("InitRandomForestRegressor failed: %v\n", err)
+	}
+	forest.Seed = 5
+
+	err = forest.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("RandomForestRegressor Fit failed: %v\n", err)
+	}
+
+	predictions, err := forest.Predict(features)
+	if err != nil {
+		t.Fatalf("RandomForestRegressor Predict failed: %v\n", err)
+	}
+
+	r2, err := R2Score(targets, predictions)
+	if err != nil {
+		t.Fatalf("R2Score failed: %v\n", err)
+	}
+
+	if r2 < 0.95 {
+		t.Errorf("Expected training R2 above 0.95, got %v", r2)
+	}
+
+	if forest.OOBScore < 0.8 {
+		t.Errorf("Expected out-of-bag R2 above 0.8, got %v", forest.OOBScore)
+	}
+
+	if forest.FeatureImportances[0] <= forest.FeatureImportances[1] {
+		t.Errorf("The informative feature should dominate importances, got %v", forest.FeatureImportances)
+	}
+}
+
+func TestRandomForestErrors(t *testing.T) {
+	_, err := InitRandomForestClassifier[float64, uint64](0, 0, 0)
+	if err == nil {
+		t.Errorf("Expected an error for a forest without trees")
+	}
+
+	forest, _ := InitRandomForestRegressor[float64, uint64](5, 0, 0)
+	features, _ := InitTensor64(4, 2)
+
+	_, err = forest.Predict(features)
+	if err == nil {
+		t.Errorf("Expected an error when predicting before Fit")
+	}
+
+	targets, _ := InitTensor64(3, 1)
+	err = forest.Fit(features, targets)
+	if err == nil {
+		t.Errorf("Expected an error for mismatched targets")
+	}
+}
@@ -0,0 +1,150 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type Penalty int
+
+const (
+	NoPenalty Penalty = iota
+	L2Penalty
+	L1Penalty
+	ElasticNetPenalty
+)
+
+// Regularization adds Strength * (L1Ratio * |w|_1 + (1 - L1Ratio) / 2 * ||w||^2)
+// to a model's cost. L1Ratio is only read for ElasticNetPenalty; L1Penalty and
+// L2Penalty behave as ratios of 1 and 0.
+type Regularization[T Numeric] struct {
+	Penalty		Penalty
+	Strength	T
+	L1Ratio		T
+}
+
+func (reg Regularization[T]) Validate() error {
+	if float64(reg.Strength) < 0 {
+		return fmt.Errorf("Regularization strength must be non-negative, got %v", reg.Strength)
+	}
+
+	switch reg.Penalty {
+	case NoPenalty, L1Penalty, L2Penalty:
+	case ElasticNetPenalty:
+		ratio := float64(reg.L1Ratio)
+		if ratio < 0 || ratio > 1 {
+			return fmt.Errorf("ElasticNet L1Ratio must be in [0, 1], got %v", reg.L1Ratio)
+		}
+	default:
+		return errors.New("Unknown regularization penalty")
+	}
+
+	return nil
+}
+
+func (reg Regularization[T]) coefficients() (float64, float64) {
+	strength := float64(reg.Strength)
+
+	switch reg.Penalty {
+	case L1Penalty:
+		return strength, 0.0
+	case L2Penalty:
+		return 0.0, strength
+	case ElasticNetPenalty:
+		ratio := float64(reg.L1Ratio)
+		return strength * ratio, strength * (1.0 - ratio)
+	}
+
+	return 0.0, 0.0
+}
+
+// PenaltyGradient returns the (sub)gradient of the penalty with respect to a
+// [numFeatures, 1] weight tensor. When skipBias is set, the first row is
+// treated as the bias column added by AugmentBias and is left unpenalized.
+func PenaltyGradient[T Numeric, S Index](reg Regularization[T], weights *Tensor[T, S], skipBias bool) (*Tensor[T, S], error) {
+	result, err := InitTensor[T, S](weights.Shape)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	err = AddPenaltyGradient(reg, weights, result, skipBias)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return result, nil
+}
+
+// AddPenaltyGradient adds the penalty (sub)gradient to gradient in place, so
+// the training loops need no separate penalty buffer.
+func AddPenaltyGradient[T Numeric, S Index](reg Regularization[T], weights, gradient *Tensor[T, S], skipBias bool) error {
+	l1, l2 := reg.coefficients()
+	if l1 == 0 && l2 == 0 {
+		return nil
+	}
+
+	_, values, out, err := binaryIntoSlices(gradient, gradient, weights)
+	if err != nil {
+		return err
+	}
+
+	start := 0
+	if skipBias {
+		start = int(weights.Shape[1])
+	}
+
+	for n := start; n < len(values); n++ {
+		w := float64(values[n])
+
+		sign := 0.0
+		if w > 0 {
+			sign = 1.0
+		} else if w < 0 {
+			sign = -1.0
+		}
+
+		out[n] += T(l1 * sign + l2 * w)
+	}
+
+	return nil
+}
+
+func PenaltyCost[T Numeric, S Index](reg Regularization[T], weights *Tensor[T, S], skipBias bool) (T, error) {
+	l1, l2 := reg.coefficients()
+	if l1 == 0 && l2 == 0 {
+		return T(0), nil
+	}
+
+	values, err := weights.elements()
+	if err != nil {
+		return T(0), err
+	}
+
+	start := 0
+	if skipBias {
+		start = int(weights.Shape[1])
+	}
+
+	absSum := 0.0
+	sumOfSquares := 0.0
+	for n := start; n < len(values); n++ {
+		w := float64(values[n])
+		absSum += math.Abs(w)
+		sumOfSquares += w * w
+	}
+
+	return T(l1 * absSum + 0.5 * l2 * sumOfSquares), nil
+}
+
+func softThreshold(val, threshold float64) float64 {
+	if val > threshold {
+		return val - threshold
+	}
+
+	if val < -threshold {
+		return val + threshold
+	}
+
+	return 0.0
+}
@@ -0,0 +1,214 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func ridgeClosedForm(X, y *Tensor[float64, uint64], strength float64) *Tensor[float64, uint64] {
+	X_T, _ := X.Transpose()
+	gram, _ := X_T.Dot(X)
+
+	numFeatures := gram.Shape[0]
+	for m := uint64(1); m < numFeatures; m++ {
+		gram.Data[m * numFeatures + m] += strength
+	}
+
+	inverse, _ := PInv(gram)
+	projected, _ := X_T.Dot(y)
+	weights, _ := inverse.Dot(projected)
+
+	return weights
+}
+
+func TestPenaltyGradientSkipsBias(t *testing.T) {
+	weights, _ := InitTensor64(3, 1)
+	weights.Data = []float64{5.0, -2.0, 0.5}
+
+	reg := Regularization[float64]{Penalty: ElasticNetPenalty, Strength: 2.0, L1Ratio: 0.5}
+
+	gradient, err := PenaltyGradient(reg, weights, true)
+	if err != nil {
+		t.Fatalf("PenaltyGradient failed: %v\n", err)
+	}
+
+	expected := []float64{0.0, -1.0 + -2.0, 1.0 + 0.5}
+	for n := range expected {
+		if math.Abs(gradient.Data[n] - expected[n]) > 1e-12 {
+			t.Errorf("Unexpected penalty gradient: got %v, expected %v", gradient.Data, expected)
+			break
+		}
+	}
+
+	cost, _ := PenaltyCost(reg, weights, true)
+	expectedCost := 1.0 * 2.5 + 0.5 * 1.0 * 4.25
+	if math.Abs(cost - expectedCost) > 1e-12 {
+		t.Errorf("Unexpected penalty cost: got %v, expected %v", cost, expectedCost)
+	}
+}
+
+func TestPenaltySkipsBiasRowOfView(t *testing.T) {
+	// the transpose of a [2, 3] tensor is a [3, 2] view whose first row is
+	// its first column, so skipping the bias row skips two elements
+	stored, _ := InitTensor64(2, 3)
+	stored.Data = []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}
+	weights, _ := stored.Transpose()
+
+	reg := Regularization[float64]{Penalty: L1Penalty, Strength: 1.0}
+
+	cost, err := PenaltyCost(reg, weights, true)
+	if err != nil {
+		t.Fatalf("PenaltyCost failed: %v\n", err)
+	}
+
+	if cost != 2.0 + 5.0 + 3.0 + 6.0 {
+		t.Errorf("Unexpected penalty cost of a view: %v", cost)
+	}
+
+	gradient, _ := PenaltyGradient(reg, weights, true)
+	expected := []float64{0.0, 0.0, 1.0, 1.0, 1.0, 1.0}
+	for n := range expected {
+		if gradient.Data[n] != expected[n] {
+			t.Errorf("Unexpected penalty gradient of a view: %v", gradient.Data)
+			break
+		}
+	}
+}
+
+func TestRegularizationValidate(t *testing.T) {
+	invalid := []Regularization[float64] {
+		{Penalty: L2Penalty, Strength: -1.0},
+		{Penalty: ElasticNetPenalty, Strength: 1.0, L1Ratio: 1.5},
+		{Penalty: Penalty(42), Strength: 1.0},
+	}
+
+	for _, reg := range invalid {
+		if reg.Validate() == nil {
+			t.Errorf("Invalid regularization accepted: %v", reg)
+		}
+	}
+}
+
+func TestRidgeMatchesClosedForm(t *testing.T) {
+	tol := 1e-4
+	strength := 50.0
+
+	xBase, _ := InitRandomTensor64(5.0, 100, 2)
+	y, _ := InitTargetTensor(xBase, []float64{3.0, 2.0, -1.0})
+	X, _ := xBase.AugmentBias()
+
+	expected := ridgeClosedForm(X, y, strength)
+
+	descent, _ := InitLinearRegressionModel[float64, uint64](3, 0.001, 0.0, 0.0, 5000)
+	descent.Regularization = Regularization[float64]{Penalty: L2Penalty, Strength: strength}
+
+	err := descent.Fit(X, y)
+	if err != nil {
+		t.Fatalf("Ridge Fit failed: %v\n", err)
+	}
+
+	coordinate, _ := InitLinearRegressionModel[float64, uint64](3, 0.0, 0.0, 0.0, 1000)
+	coordinate.Regularization = Regularization[float64]{Penalty: L2Penalty, Strength: strength}
+
+	err = coordinate.FitCoordinateDescent(X, y)
+	if err != nil {
+		t.Fatalf("Ridge FitCoordinateDescent failed: %v\n", err)
+	}
+
+	for n := range expected.Data {
+		if math.Abs(descent.Weights.Data[n] - expected.Data[n]) > tol {
+			t.Errorf("Gradient ridge weights differ from closed form: got %v, expected %v", descent.Weights.Data, expected.Data)
+			break
+		}
+	}
+
+	for n := range expected.Data {
+		if math.Abs(coordinate.Weights.Data[n] - expected.Data[n]) > tol {
+			t.Errorf("Coordinate descent ridge weights differ from closed form: got %v, expected %v", coordinate.Weights.Data, expected.Data)
+			break
+		}
+	}
+
+	unregularized := ridgeClosedForm(X, y, 0.0)
+	shrunk, _ := coordinate.Weights.GetBatchSlice(1, 2)
+	free, _ := unregularized.GetBatchSlice(1, 2)
+	shrunkNorm, _ := shrunk.Norm()
+	freeNorm, _ := free.Norm()
+
+	if shrunkNorm >= freeNorm {
+		t.Errorf("Ridge did not shrink the weights: %v >= %v", shrunkNorm, freeNorm)
+	}
+}
+
+func TestLassoZeroesIrrelevantFeature(t *testing.T) {
+	numSamples := uint64(100)
+
+	xBase, _ := InitRandomTensor64(5.0, numSamples, 3)
+	y, _ := InitTensor64(numSamples, 1)
+	for n := uint64(0); n < numSamples; n++ {
+		y.Data[n] = 1.0 + 4.0 * xBase.Data[n * 3] - 3.0 * xBase.Data[n * 3 + 1]
+	}
+
+	X, _ := xBase.AugmentBias()
+
+	lasso, _ := InitLinearRegressionModel[float64, uint64](4, 0.0, 0.0, 0.0, 1000)
+	lasso.Regularization = Regularization[float64]{Penalty: L1Penalty, Strength: 10.0}
+
+	err := lasso.FitCoordinateDescent(X, y)
+	if err != nil {
+		t.Fatalf("Lasso FitCoordinateDescent failed: %v\n", err)
+	}
+
+	if lasso.Weights.Data[3] != 0.0 {
+		t.Errorf("Lasso did not zero the irrelevant feature: %v", lasso.Weights.Data)
+	}
+
+	expected := []float64{1.0, 4.0, -3.0}
+	for n := range expected {
+		if math.Abs(lasso.Weights.Data[n] - expected[n]) > 0.1 {
+			t.Errorf("Unexpected lasso weights: got %v, expected about %v", lasso.Weights.Data, expected)
+			break
+		}
+	}
+
+	// a huge penalty removes every feature but leaves the bias at the mean
+	lasso.Regularization.Strength = 1e9
+	lasso.FitCoordinateDescent(X, y)
+
+	yMean, _ := y.Mean()
+	if lasso.Weights.Data[1] != 0 || lasso.Weights.Data[2] != 0 || lasso.Weights.Data[3] != 0 {
+		t.Errorf("Large L1 penalty should zero all feature weights: %v", lasso.Weights.Data)
+	}
+
+	if math.Abs(lasso.Weights.Data[0] - yMean) > 1e-6 {
+		t.Errorf("Bias should not be penalized: got %v, expected %v", lasso.Weights.Data[0], yMean)
+	}
+}
+
+func TestLogisticRegressionL2Shrinks(t *testing.T) {
+	features, _ := InitTensor64(4, 2)
+	features.Data = []float64{1.0, 1.0, -1.0, -1.0, 1.0, -1.0, -1.0, 1.0}
+
+	targets, _ := InitTensor64(4, 1)
+	targets.Data = []float64{1.0, 0.0, 0.0, 0.0}
+
+	free, _ := InitLogisticRegression[float64, uint64](2, 0.0, 0.1, 2000)
+	err := free.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("Unregularized logistic Fit failed: %v\n", err)
+	}
+
+	ridge, _ := InitLogisticRegression[float64, uint64](2, 0.0, 0.1, 2000)
+	ridge.Regularization = Regularization[float64]{Penalty: L2Penalty, Strength: 0.5}
+	err = ridge.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("Regularized logistic Fit failed: %v\n", err)
+	}
+
+	freeNorm, _ := free.Weights.Norm()
+	ridgeNorm, _ := ridge.Weights.Norm()
+
+	if ridgeNorm >= freeNorm {
+		t.Errorf("L2 penalty did not shrink logistic weights: %v >= %v", ridgeNorm, freeNorm)
+	}
+}
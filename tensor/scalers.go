@@ -0,0 +1,368 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Transformer is implemented by every preprocessing step: Fit learns whatever
+// statistics the step needs from training features, and Transform applies
+// them to a [N, F] tensor.
+type Transformer[T Numeric, S Index] interface {
+	Fit(features *Tensor[T, S]) error
+	Transform(input *Tensor[T, S]) (*Tensor[T, S], error)
+}
+
+// InvertibleTransformer can map transformed features back to the original
+// units.
+type InvertibleTransformer[T Numeric, S Index] interface {
+	Transformer[T, S]
+	InverseTransform(input *Tensor[T, S]) (*Tensor[T, S], error)
+}
+
+// FitTransform fits the transformer on features and returns them transformed.
+func FitTransform[T Numeric, S Index](transformer Transformer[T, S], features *Tensor[T, S]) (*Tensor[T, S], error) {
+	err := transformer.Fit(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return transformer.Transform(features)
+}
+
+// featureColumns returns the columns of a 2D tensor as float64 slices.
+func featureColumns[T Numeric, S Index](input *Tensor[T, S]) ([][]float64, error) {
+	rows, err := matrixToFloat64(input)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([][]float64, input.Shape[1])
+	for m := range columns {
+		columns[m] = make([]float64, len(rows))
+		for n, row := range rows {
+			columns[m][n] = row[m]
+		}
+	}
+
+	return columns, nil
+}
+
+// scaleColumns computes (x - center) / scale for every feature, or
+// x * scale + center when inverse is set.
+func scaleColumns[T Numeric, S Index](input *Tensor[T, S], center, scale []float64, inverse bool, name string) (*Tensor[T, S], error) {
+	if len(scale) == 0 {
+		return &Tensor[T, S]{}, fmt.Errorf("%v must be fitted (call Fit)", name)
+	}
+
+	if len(input.Shape) != 2 {
+		return &Tensor[T, S]{}, fmt.Errorf("%v requires a 2D tensor", name)
+	}
+
+	if input.Shape[1] != S(len(scale)) {
+		return &Tensor[T, S]{}, fmt.Errorf("Expecting %v features, got %v", len(scale), input.Shape[1])
+	}
+
+	rows, err := matrixToFloat64(input)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for _, row := range rows {
+		for m, val := range row {
+			if inverse {
+				row[m] = val * scale[m] + center[m]
+			} else {
+				row[m] = (val - center[m]) / scale[m]
+			}
+		}
+	}
+
+	return float64ToMatrix[T, S](rows, input.Shape[0], input.Shape[1])
+}
+
+// nonZeroScale keeps constant features from dividing by zero, as
+// StandardScaler does for a zero standard deviation.
+func nonZeroScale(scale float64) float64 {
+	if scale < 1e-9 {
+		return 1.0
+	}
+
+	return scale
+}
+
+func toFloat64s[T Numeric](values []T) []float64 {
+	result := make([]float64, len(values))
+	for n, val := range values {
+		result[n] = float64(val)
+	}
+
+	return result
+}
+
+// MinMaxScaler maps every feature linearly onto FeatureRange, using the
+// minimum and maximum seen during Fit.
+type MinMaxScaler[T Numeric, S Index] struct {
+	FeatureRange	[2]T
+	DataMin		[]T
+	DataMax		[]T
+}
+
+func InitMinMaxScaler[T Numeric, S Index](low, high T) (*MinMaxScaler[T, S], error) {
+	if low >= high {
+		return &MinMaxScaler[T, S]{}, fmt.Errorf("MinMaxScaler range must be increasing, got [%v, %v]", low, high)
+	}
+
+	return &MinMaxScaler[T, S]{FeatureRange: [2]T{low, high}}, nil
+}
+
+func (scaler *MinMaxScaler[T, S]) Fit(features *Tensor[T, S]) error {
+	if scaler.FeatureRange[0] >= scaler.FeatureRange[1] {
+		return fmt.Errorf("MinMaxScaler range must be increasing, got %v", scaler.FeatureRange)
+	}
+
+	columns, err := featureColumns(features)
+	if err != nil {
+		return err
+	}
+
+	if features.Shape[0] == 0 {
+		return errors.New("MinMaxScaler Fit requires at least one sample")
+	}
+
+	scaler.DataMin = make([]T, len(columns))
+	scaler.DataMax = make([]T, len(columns))
+	for m, column := range columns {
+		low := math.Inf(1)
+		high := math.Inf(-1)
+		for _, val := range column {
+			low = math.Min(low, val)
+			high = math.Max(high, val)
+		}
+
+		scaler.DataMin[m] = T(low)
+		scaler.DataMax[m] = T(high)
+	}
+
+	return nil
+}
+
+// affine expresses the scaler as (x - center) / scale.
+func (scaler *MinMaxScaler[T, S]) affine() ([]float64, []float64) {
+	low := float64(scaler.FeatureRange[0])
+	high := float64(scaler.FeatureRange[1])
+
+	center := make([]float64, len(scaler.DataMin))
+	scale := make([]float64, len(scaler.DataMin))
+	for m := range scale {
+		dataMin := float64(scaler.DataMin[m])
+		scale[m] = nonZeroScale(float64(scaler.DataMax[m]) - dataMin) / (high - low)
+		center[m] = dataMin - low * scale[m]
+	}
+
+	return center, scale
+}
+
+func (scaler *MinMaxScaler[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	center, scale := scaler.affine()
+	return scaleColumns(input, center, scale, false, "MinMaxScaler")
+}
+
+func (scaler *MinMaxScaler[T, S]) InverseTransform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	center, scale := scaler.affine()
+	return scaleColumns(input, center, scale, true, "MinMaxScaler")
+}
+
+// MaxAbsScaler divides every feature by its largest absolute value, mapping
+// it into [-1, 1] without shifting it, so zeros stay zero.
+type MaxAbsScaler[T Numeric, S Index] struct {
+	MaxAbs	[]T
+}
+
+func (scaler *MaxAbsScaler[T, S]) Fit(features *Tensor[T, S]) error {
+	columns, err := featureColumns(features)
+	if err != nil {
+		return err
+	}
+
+	scaler.MaxAbs = make([]T, len(columns))
+	for m, column := range columns {
+		largest := 0.0
+		for _, val := range column {
+			largest = math.Max(largest, math.Abs(val))
+		}
+
+		scaler.MaxAbs[m] = T(largest)
+	}
+
+	return nil
+}
+
+func (scaler *MaxAbsScaler[T, S]) affine() ([]float64, []float64) {
+	center := make([]float64, len(scaler.MaxAbs))
+	scale := make([]float64, len(scaler.MaxAbs))
+	for m, val := range scaler.MaxAbs {
+		scale[m] = nonZeroScale(float64(val))
+	}
+
+	return center, scale
+}
+
+func (scaler *MaxAbsScaler[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	center, scale := scaler.affine()
+	return scaleColumns(input, center, scale, false, "MaxAbsScaler")
+}
+
+func (scaler *MaxAbsScaler[T, S]) InverseTransform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	center, scale := scaler.affine()
+	return scaleColumns(input, center, scale, true, "MaxAbsScaler")
+}
+
+// RobustScaler centers every feature on its median and divides by its
+// interquartile range (or whichever QuantileRange is set), so a few extreme
+// outliers barely move the statistics.
+type RobustScaler[T Numeric, S Index] struct {
+	QuantileRange	[2]float64
+	Center		[]T
+	Scale		[]T
+}
+
+// InitRobustScaler takes the lower and upper percentiles of the range used as
+// the scale, 25 and 75 for the interquartile range.
+func InitRobustScaler[T Numeric, S Index](lowerPercentile, upperPercentile float64) (*RobustScaler[T, S], error) {
+	scaler := &RobustScaler[T, S]{QuantileRange: [2]float64{lowerPercentile, upperPercentile}}
+
+	err := scaler.validate()
+	if err != nil {
+		return &RobustScaler[T, S]{}, err
+	}
+
+	return scaler, nil
+}
+
+func (scaler *RobustScaler[T, S]) validate() error {
+	low, high := scaler.QuantileRange[0], scaler.QuantileRange[1]
+	if low < 0 || high > 100 || low >= high {
+		return fmt.Errorf("RobustScaler percentiles must satisfy 0 <= low < high <= 100, got %v", scaler.QuantileRange)
+	}
+
+	return nil
+}
+
+// quantile interpolates linearly between the closest ranks of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted) - 1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+
+	fraction := position - float64(lower)
+	return sorted[lower] + fraction * (sorted[upper] - sorted[lower])
+}
+
+func (scaler *RobustScaler[T, S]) Fit(features *Tensor[T, S]) error {
+	err := scaler.validate()
+	if err != nil {
+		return err
+	}
+
+	columns, err := featureColumns(features)
+	if err != nil {
+		return err
+	}
+
+	if features.Shape[0] == 0 {
+		return errors.New("RobustScaler Fit requires at least one sample")
+	}
+
+	scaler.Center = make([]T, len(columns))
+	scaler.Scale = make([]T, len(columns))
+	for m, column := range columns {
+		sort.Float64s(column)
+
+		spread := quantile(column, scaler.QuantileRange[1] / 100.0) - quantile(column, scaler.QuantileRange[0] / 100.0)
+
+		scaler.Center[m] = T(quantile(column, 0.5))
+		scaler.Scale[m] = T(nonZeroScale(spread))
+	}
+
+	return nil
+}
+
+func (scaler *RobustScaler[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return scaleColumns(input, toFloat64s(scaler.Center), toFloat64s(scaler.Scale), false, "RobustScaler")
+}
+
+func (scaler *RobustScaler[T, S]) InverseTransform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	return scaleColumns(input, toFloat64s(scaler.Center), toFloat64s(scaler.Scale), true, "RobustScaler")
+}
+
+type NormType int
+
+const (
+	L1Norm NormType = iota
+	L2Norm
+	MaxNorm
+)
+
+// Normalizer rescales every sample (row) to unit norm. It keeps no
+// statistics, so Fit only checks the input, and it has no InverseTransform
+// because the original norms are discarded.
+type Normalizer[T Numeric, S Index] struct {
+	Norm	NormType
+}
+
+func InitNormalizer[T Numeric, S Index](norm NormType) (*Normalizer[T, S], error) {
+	if norm < L1Norm || norm > MaxNorm {
+		return &Normalizer[T, S]{}, fmt.Errorf("Unknown norm %v", norm)
+	}
+
+	return &Normalizer[T, S]{Norm: norm}, nil
+}
+
+func (normalizer *Normalizer[T, S]) Fit(features *Tensor[T, S]) error {
+	if len(features.Shape) != 2 {
+		return errors.New("Normalizer requires a 2D tensor")
+	}
+
+	return nil
+}
+
+func (normalizer *Normalizer[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	rows, err := matrixToFloat64(input)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for _, row := range rows {
+		norm := 0.0
+		for _, val := range row {
+			switch normalizer.Norm {
+			case L1Norm:
+				norm += math.Abs(val)
+			case L2Norm:
+				norm += val * val
+			case MaxNorm:
+				norm = math.Max(norm, math.Abs(val))
+			default:
+				return &Tensor[T, S]{}, fmt.Errorf("Unknown norm %v", normalizer.Norm)
+			}
+		}
+
+		if normalizer.Norm == L2Norm {
+			norm = math.Sqrt(norm)
+		}
+
+		// all-zero rows are left as they are
+		if norm == 0 {
+			continue
+		}
+
+		for m := range row {
+			row[m] /= norm
+		}
+	}
+
+	return float64ToMatrix[T, S](rows, input.Shape[0], input.Shape[1])
+}
@@ -0,0 +1,160 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func scalerTestData() *Tensor[float64, uint64] {
+	input, _ := InitTensor64(5, 2)
+	input.Data = []float64{
+		1.0, -4.0,
+		2.0, 0.0,
+		3.0, 2.0,
+		4.0, 2.0,
+		100.0, 8.0,
+	}
+
+	return input
+}
+
+func assertClose(t *testing.T, name string, actual, expected []float64) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("%v: expected %v values, got %v", name, len(expected), len(actual))
+	}
+
+	for n := range expected {
+		if math.Abs(actual[n] - expected[n]) > 1e-9 {
+			t.Errorf("%v: got %v, expected %v", name, actual, expected)
+			return
+		}
+	}
+}
+
+func TestScalersRoundTrip(t *testing.T) {
+	minMax, _ := InitMinMaxScaler[float64, uint64](-1.0, 1.0)
+	robust, _ := InitRobustScaler[float64, uint64](25, 75)
+
+	scalers := map[string]InvertibleTransformer[float64, uint64] {
+		"StandardScaler":	&StandardScaler[float64, uint64]{},
+		"MinMaxScaler":		minMax,
+		"MaxAbsScaler":		&MaxAbsScaler[float64, uint64]{},
+		"RobustScaler":		robust,
+	}
+
+	input := scalerTestData()
+	for name, scaler := range scalers {
+		transformed, err := FitTransform[float64, uint64](scaler, input)
+		if err != nil {
+			t.Fatalf("%v FitTransform failed: %v", name, err)
+		}
+
+		restored, err := scaler.InverseTransform(transformed)
+		if err != nil {
+			t.Fatalf("%v InverseTransform failed: %v", name, err)
+		}
+
+		assertClose(t, name, restored.Data, input.Data)
+	}
+}
+
+func TestMinMaxScaler(t *testing.T) {
+	scaler, err := InitMinMaxScaler[float64, uint64](0.0, 2.0)
+	if err != nil {
+		t.Fatalf("InitMinMaxScaler failed: %v", err)
+	}
+
+	transformed, err := FitTransform[float64, uint64](scaler, scalerTestData())
+	if err != nil {
+		t.Fatalf("MinMaxScaler failed: %v", err)
+	}
+
+	assertClose(t, "MinMaxScaler", transformed.Data, []float64{
+		0.0, 0.0,
+		2.0 / 99.0, 2.0 / 3.0,
+		4.0 / 99.0, 1.0,
+		6.0 / 99.0, 1.0,
+		2.0, 2.0,
+	})
+
+	_, err = InitMinMaxScaler[float64, uint64](1.0, 1.0)
+	if err == nil {
+		t.Errorf("Expected an error for an empty feature range")
+	}
+}
+
+func TestMaxAbsScaler(t *testing.T) {
+	scaler := &MaxAbsScaler[float64, uint64]{}
+
+	transformed, err := FitTransform[float64, uint64](scaler, scalerTestData())
+	if err != nil {
+		t.Fatalf("MaxAbsScaler failed: %v", err)
+	}
+
+	assertClose(t, "MaxAbsScaler", transformed.Data, []float64{
+		0.01, -0.5,
+		0.02, 0.0,
+		0.03, 0.25,
+		0.04, 0.25,
+		1.0, 1.0,
+	})
+}
+
+func TestRobustScaler(t *testing.T) {
+	scaler, err := InitRobustScaler[float64, uint64](25, 75)
+	if err != nil {
+		t.Fatalf("InitRobustScaler failed: %v", err)
+	}
+
+	err = scaler.Fit(scalerTestData())
+	if err != nil {
+		t.Fatalf("RobustScaler Fit failed: %v", err)
+	}
+
+	// the outlier at 100 does not move the median or the quartiles
+	assertClose(t, "RobustScaler center", scaler.Center, []float64{3.0, 2.0})
+	assertClose(t, "RobustScaler scale", scaler.Scale, []float64{2.0, 2.0})
+
+	_, err = InitRobustScaler[float64, uint64](75, 25)
+	if err == nil {
+		t.Errorf("Expected an error for a decreasing quantile range")
+	}
+
+	unfitted := &RobustScaler[float64, uint64]{}
+	_, err = unfitted.Transform(scalerTestData())
+	if err == nil {
+		t.Errorf("Expected an error transforming before Fit")
+	}
+}
+
+func TestNormalizer(t *testing.T) {
+	input, _ := InitTensor64(3, 2)
+	input.Data = []float64{3.0, -4.0, 0.0, 0.0, 1.0, 1.0}
+
+	expected := map[NormType][]float64 {
+		L1Norm:		{3.0 / 7.0, -4.0 / 7.0, 0.0, 0.0, 0.5, 0.5},
+		L2Norm:		{0.6, -0.8, 0.0, 0.0, 1.0 / math.Sqrt2, 1.0 / math.Sqrt2},
+		MaxNorm:	{0.75, -1.0, 0.0, 0.0, 1.0, 1.0},
+	}
+
+	for norm, values := range expected {
+		normalizer, err := InitNormalizer[float64, uint64](norm)
+		if err != nil {
+			t.Fatalf("InitNormalizer failed: %v", err)
+		}
+
+		transformed, err := FitTransform[float64, uint64](normalizer, input)
+		if err != nil {
+			t.Fatalf("Normalizer failed: %v", err)
+		}
+
+		assertClose(t, "Normalizer", transformed.Data, values)
+	}
+
+	_, err := InitNormalizer[float64, uint64](NormType(7))
+	if err == nil {
+		t.Errorf("Expected an error for an unknown norm")
+	}
+}
@@ -0,0 +1,384 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type MulticlassStrategy int
+
+const (
+	MultinomialStrategy MulticlassStrategy = iota
+	OneVsRestStrategy
+)
+
+type SoftmaxRegressionModel[T Numeric, S Index] struct {
+	Weights		*Tensor[T, S]
+	Bias		[]T
+	Classes		[]string
+	LearningRate	T
+	NumIterations	S
+	BatchSize	S
+	Strategy	MulticlassStrategy
+	Scaler		*StandardScaler[T, S]
+	Regularization	Regularization[T]
+	CostHistory	[]T
+	BinaryModels	[]*LogisticRegressionModel[T, S]
+}
+
+func InitSoftmaxRegression[T Numeric, S Index](
+	learningRate T,
+	numIterations S,
+	strategy MulticlassStrategy) (*SoftmaxRegressionModel[T, S], error) {
+
+	if strategy != MultinomialStrategy && strategy != OneVsRestStrategy {
+		return &SoftmaxRegressionModel[T, S]{}, errors.New("Unknown multiclass strategy")
+	}
+
+	model := &SoftmaxRegressionModel[T, S] {
+		LearningRate:	learningRate,
+		NumIterations:	numIterations,
+		BatchSize:	128,
+		Strategy:	strategy,
+		CostHistory:	make([]T, 0, numIterations),
+	}
+
+	return model, nil
+}
+
+func encodeLabels(labels []string) ([]string, []int) {
+	seen := make(map[string]bool)
+	classes := make([]string, 0)
+
+	for _, label := range labels {
+		if !seen[label] {
+			seen[label] = true
+			classes = append(classes, label)
+		}
+	}
+
+	sort.Strings(classes)
+
+	indexByClass := make(map[string]int, len(classes))
+	for n, class := range classes {
+		indexByClass[class] = n
+	}
+
+	encoded := make([]int, len(labels))
+	for n, label := range labels {
+		encoded[n] = indexByClass[label]
+	}
+
+	return classes, encoded
+}
+
+func (model *SoftmaxRegressionModel[T, S]) Fit(features *Tensor[T, S], labels []string) error {
+	if len(features.Shape) != 2 {
+		return errors.New("SoftmaxRegression Fit requires a 2D feature tensor")
+	}
+
+	numSamples := features.Shape[0]
+	numFeatures := features.Shape[1]
+
+	if numSamples == 0 {
+		return errors.New("SoftmaxRegression Fit requires at least one sample")
+	}
+
+	if S(len(labels)) != numSamples {
+		return fmt.Errorf("Expecting %v labels, got %v", numSamples, len(labels))
+	}
+
+	err := model.Regularization.Validate()
+	if err != nil {
+		return err
+	}
+
+	classes, encoded := encodeLabels(labels)
+	if len(classes) < 2 {
+		return errors.New("SoftmaxRegression requires at least two classes")
+	}
+
+	model.Classes = classes
+	numClasses := S(len(classes))
+
+	model.Scaler = &StandardScaler[T, S]{}
+	err = model.Scaler.FitStatistics(features)
+	if err != nil {
+		return err
+	}
+
+	scaledFeatures, err := model.Scaler.Transform(features)
+	if err != nil {
+		return err
+	}
+
+	if model.Strategy == OneVsRestStrategy {
+		return model.fitOneVsRest(scaledFeatures, encoded)
+	}
+
+	oneHot, err := InitTensor[T, S]([]S{numSamples, numClasses})
+	if err != nil {
+		return err
+	}
+
+	for n, class := range encoded {
+		oneHot.Data[S(n) * numClasses + S(class)] = T(1.0)
+	}
+
+	maxVal := float64(0.01)
+	model.Weights, err = InitRandomTensor[T, S]([]S{numFeatures, numClasses}, T(maxVal))
+	if err != nil {
+		return fmt.Errorf("Failed to create weights tensor during SoftmaxRegression Fit: %v", err)
+	}
+
+	model.Bias = make([]T, numClasses)
+
+	batchSize := model.BatchSize
+	if batchSize == 0 {
+		batchSize = numSamples
+	}
+	numBatches := (numSamples + batchSize - 1) / batchSize
+
+	gradient, err := InitTensor[T, S](model.Weights.Shape)
+	if err != nil {
+		return err
+	}
+
+	for n := S(0); n < model.NumIterations; n++ {
+		if !model.Weights.Valid() {
+			return fmt.Errorf("NaN or Inf found in Softmax Regression at iteration %v\n", n)
+		}
+
+		err = ShuffleTensors(scaledFeatures, oneHot)
+		if err != nil {
+			return err
+		}
+
+		for batchNum := S(0); batchNum < numBatches; batchNum++ {
+			startRow := batchNum * batchSize
+			batchSampleCount := min(startRow + batchSize, numSamples) - startRow
+
+			featureBatch, err := scaledFeatures.GetBatchSlice(startRow, batchSampleCount)
+			if err != nil {
+				return err
+			}
+
+			targetBatch, err := oneHot.GetBatchSlice(startRow, batchSampleCount)
+			if err != nil {
+				return err
+			}
+
+			probabilities, err := model.predictScaled(featureBatch)
+			if err != nil {
+				return err
+			}
+
+			// probabilities is a fresh tensor, so it becomes the error term
+			errorTerm := probabilities
+			err = errorTerm.SubtractInPlace(targetBatch)
+			if err != nil {
+				return err
+			}
+
+			transposedFeatures, err := featureBatch.Transpose()
+			if err != nil {
+				return err
+			}
+
+			err = DotInto(gradient, transposedFeatures, errorTerm)
+			if err != nil {
+				return err
+			}
+
+			err = gradient.ScaleInPlace(T(1.0) / T(batchSampleCount))
+			if err != nil {
+				return err
+			}
+
+			err = AddPenaltyGradient(model.Regularization, model.Weights, gradient, false)
+			if err != nil {
+				return err
+			}
+
+			err = gradient.ScaleInPlace(model.LearningRate)
+			if err != nil {
+				return err
+			}
+
+			err = model.Weights.SubtractInPlace(gradient)
+			if err != nil {
+				return err
+			}
+
+			for c := S(0); c < numClasses; c++ {
+				errSum := T(0)
+				for row := S(0); row < batchSampleCount; row++ {
+					errSum += errorTerm.Data[row * numClasses + c]
+				}
+				model.Bias[c] -= model.LearningRate * errSum / T(batchSampleCount)
+			}
+		}
+
+		fullPrediction, err := model.predictScaled(scaledFeatures)
+		if err != nil {
+			return err
+		}
+
+		cost, err := CategoricalCrossEntropy(fullPrediction, oneHot)
+		if err != nil {
+			return err
+		}
+
+		penaltyCost, err := PenaltyCost(model.Regularization, model.Weights, false)
+		if err != nil {
+			return err
+		}
+
+		model.CostHistory = append(model.CostHistory, cost + penaltyCost)
+	}
+
+	return nil
+}
+
+// fitOneVsRest trains the binary models on features that are already
+// standardized by model.Scaler. Their own Fit standardizes again, which leaves
+// standardized features unchanged, so their Predict can score inputs scaled by
+// model.Scaler.
+func (model *SoftmaxRegressionModel[T, S]) fitOneVsRest(features *Tensor[T, S], encoded []int) error {
+	numSamples := features.Shape[0]
+	numFeatures := features.Shape[1]
+
+	model.BinaryModels = make([]*LogisticRegressionModel[T, S], len(model.Classes))
+
+	for c := range model.Classes {
+		binaryTargets, err := InitTensor[T, S]([]S{numSamples, 1})
+		if err != nil {
+			return err
+		}
+
+		for n, class := range encoded {
+			if class == c {
+				binaryTargets.Data[n] = T(1.0)
+			}
+		}
+
+		binary, err := InitLogisticRegression[T, S](numFeatures, T(0.0), model.LearningRate, model.NumIterations)
+		if err != nil {
+			return err
+		}
+		if model.BatchSize > 0 {
+			binary.BatchSize = model.BatchSize
+		}
+		binary.Regularization = model.Regularization
+
+		err = binary.Fit(features, binaryTargets)
+		if err != nil {
+			return fmt.Errorf("One-vs-rest Fit failed for class %v: %v", model.Classes[c], err)
+		}
+
+		model.BinaryModels[c] = binary
+	}
+
+	return nil
+}
+
+func (model *SoftmaxRegressionModel[T, S]) predictScaled(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	logits, err := input.Dot(model.Weights)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	numClasses := S(len(model.Bias))
+	for n := range logits.Data {
+		logits.Data[n] += model.Bias[S(n) % numClasses]
+	}
+
+	return Softmax(logits)
+}
+
+// PredictProba returns an [N, C] tensor of class probabilities whose columns
+// follow the order of Classes. One-vs-rest scores are normalized per row.
+func (model *SoftmaxRegressionModel[T, S]) PredictProba(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(model.Classes) == 0 {
+		return &Tensor[T, S]{}, errors.New("SoftmaxRegression must be fitted (call Fit)")
+	}
+
+	if len(input.Shape) != 2 {
+		return &Tensor[T, S]{}, errors.New("PredictProba requires a 2D feature tensor")
+	}
+
+	scaledInput, err := model.Scaler.Transform(input)
+	if err != nil {
+		return &Tensor[T, S]{}, fmt.Errorf("Scaling failed during PredictProba: %v", err)
+	}
+
+	if model.Strategy == OneVsRestStrategy {
+		return model.predictOneVsRest(scaledInput)
+	}
+
+	return model.predictScaled(scaledInput)
+}
+
+func (model *SoftmaxRegressionModel[T, S]) predictOneVsRest(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	numSamples := input.Shape[0]
+	numClasses := S(len(model.BinaryModels))
+
+	result, err := InitTensor[T, S]([]S{numSamples, numClasses})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for c, binary := range model.BinaryModels {
+		scores, err := binary.Predict(input)
+		if err != nil {
+			return &Tensor[T, S]{}, fmt.Errorf("One-vs-rest Predict failed for class %v: %v", model.Classes[c], err)
+		}
+
+		for n := S(0); n < numSamples; n++ {
+			result.Data[n * numClasses + S(c)] = scores.Data[n]
+		}
+	}
+
+	for n := S(0); n < numSamples; n++ {
+		row := result.Data[n * numClasses: (n + 1) * numClasses]
+
+		total := T(0)
+		for _, score := range row {
+			total += score
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		for c := range row {
+			row[c] /= total
+		}
+	}
+
+	return result, nil
+}
+
+func (model *SoftmaxRegressionModel[T, S]) Predict(input *Tensor[T, S]) ([]string, error) {
+	probabilities, err := model.PredictProba(input)
+	if err != nil {
+		return nil, err
+	}
+
+	numSamples := probabilities.Shape[0]
+	numClasses := probabilities.Shape[1]
+
+	predictions := make([]string, numSamples)
+	for n := S(0); n < numSamples; n++ {
+		best := S(0)
+		for c := S(1); c < numClasses; c++ {
+			if probabilities.Data[n * numClasses + c] > probabilities.Data[n * numClasses + best] {
+				best = c
+			}
+		}
+		predictions[n] = model.Classes[best]
+	}
+
+	return predictions, nil
+}
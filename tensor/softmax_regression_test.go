@@ -0,0 +1,134 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func threeClusterData() (*Tensor[float64, uint64], []string) {
+	features, _ := InitTensor64(12, 2)
+	features.Data = []float64{
+		0.0, 5.0, 0.5, 5.5, -0.5, 4.5, 0.2, 5.2,
+		5.0, 0.0, 5.5, 0.5, 4.5, -0.5, 5.2, 0.2,
+		-5.0, -5.0, -5.5, -4.5, -4.5, -5.5, -5.2, -5.2,
+	}
+
+	labels := []string{
+		"north", "north", "north", "north",
+		"east", "east", "east", "east",
+		"south", "south", "south", "south",
+	}
+
+	return features, labels
+}
+
+func TestSoftmaxRegressionMultinomial(t *testing.T) {
+	features, labels := threeClusterData()
+
+	model, err := InitSoftmaxRegression[float64, uint64](0.1, 500, MultinomialStrategy)
+	if err != nil {
+		t.Fatalf("InitSoftmaxRegression failed: %v\n", err)
+	}
+
+	err = model.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("Softmax Fit failed: %v\n", err)
+	}
+
+	expectedClasses := []string{"east", "north", "south"}
+	for n := range expectedClasses {
+		if model.Classes[n] != expectedClasses[n] {
+			t.Errorf("Unexpected class ordering: %v", model.Classes)
+		}
+	}
+
+	if model.Weights.Shape[0] != 2 || model.Weights.Shape[1] != 3 {
+		t.Errorf("Unexpected weights shape: %v", model.Weights.Shape)
+	}
+
+	if model.CostHistory[len(model.CostHistory) - 1] >= model.CostHistory[0] {
+		t.Errorf("Cost did not decrease: first %v, last %v", model.CostHistory[0], model.CostHistory[len(model.CostHistory) - 1])
+	}
+
+	predictions, err := model.Predict(features)
+	if err != nil {
+		t.Fatalf("Softmax Predict failed: %v\n", err)
+	}
+
+	for n := range labels {
+		if predictions[n] != labels[n] {
+			t.Errorf("Unexpected predictions: got %v, expected %v", predictions, labels)
+			break
+		}
+	}
+
+	probabilities, err := model.PredictProba(features)
+	if err != nil {
+		t.Fatalf("Softmax PredictProba failed: %v\n", err)
+	}
+
+	if probabilities.Shape[0] != 12 || probabilities.Shape[1] != 3 {
+		t.Errorf("Unexpected probability shape: %v", probabilities.Shape)
+	}
+
+	for n := 0; n < 12; n++ {
+		rowSum := probabilities.Data[n * 3] + probabilities.Data[n * 3 + 1] + probabilities.Data[n * 3 + 2]
+		if math.Abs(rowSum - 1.0) > 1e-9 {
+			t.Errorf("Probabilities of row %v do not sum to 1: %v", n, rowSum)
+		}
+	}
+}
+
+func TestSoftmaxRegressionOneVsRest(t *testing.T) {
+	features, labels := threeClusterData()
+
+	model, err := InitSoftmaxRegression[float64, uint64](0.1, 500, OneVsRestStrategy)
+	if err != nil {
+		t.Fatalf("InitSoftmaxRegression failed: %v\n", err)
+	}
+
+	err = model.Fit(features, labels)
+	if err != nil {
+		t.Fatalf("One-vs-rest Fit failed: %v\n", err)
+	}
+
+	if len(model.BinaryModels) != 3 {
+		t.Errorf("Expected one binary model per class, got %v", len(model.BinaryModels))
+	}
+
+	predictions, err := model.Predict(features)
+	if err != nil {
+		t.Fatalf("One-vs-rest Predict failed: %v\n", err)
+	}
+
+	for n := range labels {
+		if predictions[n] != labels[n] {
+			t.Errorf("Unexpected one-vs-rest predictions: got %v, expected %v", predictions, labels)
+			break
+		}
+	}
+
+	probabilities, _ := model.PredictProba(features)
+	for n := 0; n < 12; n++ {
+		rowSum := probabilities.Data[n * 3] + probabilities.Data[n * 3 + 1] + probabilities.Data[n * 3 + 2]
+		if math.Abs(rowSum - 1.0) > 1e-9 {
+			t.Errorf("One-vs-rest probabilities of row %v do not sum to 1: %v", n, rowSum)
+		}
+	}
+}
+
+func TestSoftmaxRegressionRejectsSingleClass(t *testing.T) {
+	features, _ := InitTensor64(2, 2)
+
+	model, _ := InitSoftmaxRegression[float64, uint64](0.1, 10, MultinomialStrategy)
+
+	err := model.Fit(features, []string{"only", "only"})
+	if err == nil {
+		t.Errorf("Single class labels accepted by Fit")
+	}
+
+	err = model.Fit(features, []string{"a"})
+	if err == nil {
+		t.Errorf("Mismatched label count accepted by Fit")
+	}
+}
@@ -11,31 +11,34 @@ type StandardScaler[T Numeric, S Index] struct {
 	Sigma	[]T
 }
 
-func (scalar *StandardScaler[T, S]) FitStatistics(trainingFeatures *Tensor[T, S]) error {
-	if len(trainingFeatures.Shape) != 2 {
-		return fmt.Errorf("StandardScalar requires a 2D tensor")
+// columnMoments returns the mean and the population variance of every column
+// of a 2D tensor. FitStatistics clamps the variances it turns into Sigma, so
+// callers that need the raw variances use this directly.
+func columnMoments[T Numeric, S Index](features *Tensor[T, S]) ([]T, []T, error) {
+	if len(features.Shape) != 2 {
+		return nil, nil, fmt.Errorf("Column moments require a 2D tensor")
 	}
 
-	numSamples := trainingFeatures.Shape[0]
-	numFeatures := trainingFeatures.Shape[1]
+	numSamples := features.Shape[0]
+	numFeatures := features.Shape[1]
 
-	scalar.Mu = make([]T, numFeatures)
-	scalar.Sigma = make([]T, numFeatures)
+	means := make([]T, numFeatures)
+	variances := make([]T, numFeatures)
 
 	const featureAxis = 1
 
 	for n := S(0); n < numFeatures; n++ {
-		columnData, err := trainingFeatures.GetSlice(featureAxis, n)
+		columnData, err := features.GetSlice(featureAxis, n)
 		if err != nil {
-			return fmt.Errorf("Error extracting feature %v: %v", n, err)
+			return nil, nil, fmt.Errorf("Error extracting feature %v: %v", n, err)
 		}
 
 		sumOfValues, err := columnData.Sum()
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		meanValue := sumOfValues / T(numSamples)
-		scalar.Mu[n] = meanValue
+		means[n] = meanValue
 
 		varianceSum := T(0.0)
 
@@ -49,7 +52,26 @@ func (scalar *StandardScaler[T, S]) FitStatistics(trainingFeatures *Tensor[T, S]
 			varianceSum += diff * diff
 		}
 
-		variance := varianceSum / T(numSamples)
+		variances[n] = varianceSum / T(numSamples)
+	}
+
+	return means, variances, nil
+}
+
+func (scalar *StandardScaler[T, S]) FitStatistics(trainingFeatures *Tensor[T, S]) error {
+	if len(trainingFeatures.Shape) != 2 {
+		return fmt.Errorf("StandardScalar requires a 2D tensor")
+	}
+
+	means, variances, err := columnMoments(trainingFeatures)
+	if err != nil {
+		return err
+	}
+
+	scalar.Mu = means
+	scalar.Sigma = make([]T, len(variances))
+
+	for n, variance := range variances {
 		stdDevValue := T(math.Sqrt(float64(variance)))
 
 		if float64(stdDevValue) < 1e-9 {
@@ -61,6 +83,11 @@ func (scalar *StandardScaler[T, S]) FitStatistics(trainingFeatures *Tensor[T, S]
 	return nil
 }
 
+// Fit is FitStatistics under the name shared by every Transformer.
+func (scalar *StandardScaler[T, S]) Fit(trainingFeatures *Tensor[T, S]) error {
+	return scalar.FitStatistics(trainingFeatures)
+}
+
 func (scaler *StandardScaler[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S], error) {
 	if len(scaler.Mu) == 0 || len(scaler.Sigma) == 0 {
 		return &Tensor[T, S]{}, fmt.Errorf("StandardScalar must be fitted (call FitStatistics)")
@@ -113,3 +140,12 @@ func (scaler *StandardScaler[T, S]) Transform(input *Tensor[T, S]) (*Tensor[T, S
 
 	return transformedData, nil
 }
+
+// InverseTransform maps standardized features back to the original units.
+func (scaler *StandardScaler[T, S]) InverseTransform(input *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(scaler.Mu) == 0 || len(scaler.Sigma) == 0 {
+		return &Tensor[T, S]{}, fmt.Errorf("StandardScalar must be fitted (call FitStatistics)")
+	}
+
+	return scaleColumns(input, toFloat64s(scaler.Mu), toFloat64s(scaler.Sigma), true, "StandardScaler")
+}
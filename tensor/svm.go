@@ -0,0 +1,210 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type LinearSVM[T Numeric, S Index] struct {
+	Weights		*Tensor[T, S]
+	Bias		T
+	Lambda		T
+	NumEpochs	S
+	SquaredHinge	bool
+	Seed		int64
+	Scaler		*StandardScaler[T, S]
+	CostHistory	[]T
+}
+
+// InitLinearSVM creates a linear support vector machine trained with the
+// Pegasos stochastic sub-gradient method. lambda is the strength of the L2
+// penalty (lambda / 2) * ||w||^2; smaller values give a harder margin.
+func InitLinearSVM[T Numeric, S Index](lambda T, numEpochs S) (*LinearSVM[T, S], error) {
+	if float64(lambda) <= 0 {
+		return &LinearSVM[T, S]{}, fmt.Errorf("LinearSVM lambda must be positive, got %v", lambda)
+	}
+
+	model := &LinearSVM[T, S] {
+		Lambda:		lambda,
+		NumEpochs:	numEpochs,
+		CostHistory:	make([]T, 0, numEpochs),
+	}
+
+	return model, nil
+}
+
+// Fit trains on 0/1 targets, the same encoding used by
+// LogisticRegressionModel and GenerateConfusionMatrix. Features are
+// standardized internally, as in logistic regression.
+func (model *LinearSVM[T, S]) Fit(features *Tensor[T, S], targets *Tensor[T, S]) error {
+	if float64(model.Lambda) <= 0 {
+		return fmt.Errorf("LinearSVM lambda must be positive, got %v", model.Lambda)
+	}
+
+	if len(features.Shape) != 2 {
+		return errors.New("LinearSVM requires a 2D feature tensor")
+	}
+
+	numSamples := int(features.Shape[0])
+	numFeatures := int(features.Shape[1])
+
+	if numSamples == 0 {
+		return errors.New("LinearSVM Fit requires at least one sample")
+	}
+
+	if len(targets.Data) != numSamples {
+		return fmt.Errorf("Expecting %v targets, got %v", numSamples, len(targets.Data))
+	}
+
+	// the hinge loss works on labels of -1 and +1
+	signs := make([]float64, numSamples)
+	for n, val := range targets.Data {
+		switch val {
+		case T(0.0):
+			signs[n] = -1.0
+		case T(1.0):
+			signs[n] = 1.0
+		default:
+			return errors.New("LinearSVM expects target values to be 1 or 0")
+		}
+	}
+
+	scaler := &StandardScaler[T, S]{}
+	err := scaler.FitStatistics(features)
+	if err != nil {
+		return err
+	}
+
+	scaledFeatures, err := scaler.Transform(features)
+	if err != nil {
+		return err
+	}
+
+	rows, err := matrixToFloat64(scaledFeatures)
+	if err != nil {
+		return err
+	}
+
+	r := newSeededRand(model.Seed)
+
+	lambda := float64(model.Lambda)
+	weights := make([]float64, numFeatures)
+	bias := 0.0
+
+	// Pegasos keeps w inside a ball that always contains the optimum. The
+	// hinge loss gives radius 1/sqrt(lambda); for the squared hinge only
+	// (lambda / 2) * ||w||^2 <= cost(w = 0) = 1 holds, so the ball is wider
+	radius := 1.0 / math.Sqrt(lambda)
+	if model.SquaredHinge {
+		radius = math.Sqrt(2.0 / lambda)
+	}
+
+	model.CostHistory = make([]T, 0, model.NumEpochs)
+
+	step := 0
+	for epoch := S(0); epoch < model.NumEpochs; epoch++ {
+		for _, n := range r.Perm(numSamples) {
+			step++
+			eta := 1.0 / (lambda * float64(step))
+
+			row := rows[n]
+			margin := signs[n] * (dotFloat64(weights, row) + bias)
+
+			for m := range weights {
+				weights[m] *= 1.0 - eta * lambda
+			}
+
+			if margin < 1.0 {
+				// hinge sub-gradient is -y*x; the squared hinge scales it by 2(1 - margin)
+				direction := signs[n]
+				if model.SquaredHinge {
+					direction *= 2.0 * (1.0 - margin)
+				}
+
+				for m := range weights {
+					weights[m] += eta * direction * row[m]
+				}
+
+				// the bias is unregularized, so it takes a plain decaying
+				// step instead of one scaled by 1/lambda
+				bias += direction / math.Sqrt(float64(step))
+			}
+
+			norm := math.Sqrt(dotFloat64(weights, weights))
+			if norm > radius {
+				for m := range weights {
+					weights[m] *= radius / norm
+				}
+			}
+		}
+
+		cost := 0.5 * lambda * dotFloat64(weights, weights)
+		for n, row := range rows {
+			loss := math.Max(0.0, 1.0 - signs[n] * (dotFloat64(weights, row) + bias))
+			if model.SquaredHinge {
+				loss *= loss
+			}
+			cost += loss / float64(numSamples)
+		}
+
+		if math.IsNaN(cost) || math.IsInf(cost, 0) {
+			return fmt.Errorf("NaN or Inf found in LinearSVM at epoch %v", epoch)
+		}
+
+		model.CostHistory = append(model.CostHistory, T(cost))
+	}
+
+	model.Weights, err = InitTensor[T, S]([]S{S(numFeatures), 1})
+	if err != nil {
+		return err
+	}
+
+	for m, val := range weights {
+		model.Weights.Data[m] = T(val)
+	}
+
+	model.Bias = T(bias)
+	model.Scaler = scaler
+
+	return nil
+}
+
+func dotFloat64(a, b []float64) float64 {
+	sum := 0.0
+	for n := range a {
+		sum += a[n] * b[n]
+	}
+
+	return sum
+}
+
+// DecisionFunction returns the [N, 1] signed margins w.x + b. Positive
+// margins predict class 1.
+func (model *LinearSVM[T, S]) DecisionFunction(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	if model.Weights == nil || model.Scaler == nil {
+		return &Tensor[T, S]{}, errors.New("LinearSVM must be fitted (call Fit)")
+	}
+
+	scaledFeatures, err := model.Scaler.Transform(features)
+	if err != nil {
+		return &Tensor[T, S]{}, fmt.Errorf("Scaling failed during DecisionFunction: %v", err)
+	}
+
+	margins, err := scaledFeatures.Dot(model.Weights)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return margins.AddScalar(model.Bias)
+}
+
+// Predict returns 0/1 class labels, ready for GenerateConfusionMatrix.
+func (model *LinearSVM[T, S]) Predict(features *Tensor[T, S]) (*Tensor[T, S], error) {
+	margins, err := model.DecisionFunction(features)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	return Classify(margins, T(0.0))
+}
@@ -0,0 +1,195 @@
+package tensor
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func svmData(overlap float64) (*Tensor[float64, uint64], *Tensor[float64, uint64]) {
+	r := rand.New(rand.NewSource(21))
+
+	features, _ := InitTensor64(200, 2)
+	targets, _ := InitTensor64(200, 1)
+
+	for n := 0; n < 200; n++ {
+		label := float64(n % 2)
+		center := 4.0 * label - 2.0
+
+		features.Data[n * 2] = center + (r.Float64() * 2.0 - 1.0) * overlap
+		features.Data[n * 2 + 1] = 10.0 + (r.Float64() * 2.0 - 1.0) * 3.0
+		targets.Data[n] = label
+	}
+
+	return features, targets
+}
+
+func TestLinearSVMSeparable(t *testing.T) {
+	features, targets := svmData(1.5)
+
+	for _, squared := range []bool{false, true} {
+		model, err := InitLinearSVM[float64, uint64](0.01, 30)
+		if err != nil {
+			t.Fatalf("InitLinearSVM failed: %v\n", err)
+		}
+		model.SquaredHinge = squared
+		model.Seed = 6
+
+		err = model.Fit(features, targets)
+		if err != nil {
+			t.Fatalf("LinearSVM Fit failed: %v\n", err)
+		}
+
+		predictions, err := model.Predict(features)
+		if err != nil {
+			t.Fatalf("LinearSVM Predict failed: %v\n", err)
+		}
+
+		matrix, err := GenerateConfusionMatrix(targets, predictions)
+		if err != nil {
+			t.Fatalf("GenerateConfusionMatrix failed: %v\n", err)
+		}
+
+		if matrix.FalsePositives + matrix.FalseNegatives != 0 {
+			t.Errorf("Expected separable data to be classified perfectly (squared hinge %v), got %+v", squared, matrix)
+		}
+
+		margins, err := model.DecisionFunction(features)
+		if err != nil {
+			t.Fatalf("LinearSVM DecisionFunction failed: %v\n", err)
+		}
+
+		if margins.Shape[0] != 200 || margins.Shape[1] != 1 {
+			t.Errorf("Unexpected margin shape: %v", margins.Shape)
+		}
+
+		// only the first feature separates the classes
+		if model.Weights.Data[0] <= 0 || model.Weights.Data[0] < 5 * math.Abs(model.Weights.Data[1]) {
+			t.Errorf("Expected the separating feature to dominate, got weights %v", model.Weights.Data)
+		}
+
+		last := model.CostHistory[len(model.CostHistory) - 1]
+		if last >= model.CostHistory[0] {
+			t.Errorf("Expected the objective to decrease, got %v -> %v", model.CostHistory[0], last)
+		}
+	}
+}
+
+func TestLinearSVMOverlapping(t *testing.T) {
+	features, targets := svmData(3.0)
+
+	model, _ := InitLinearSVM[float64, uint64](0.1, 20)
+	model.Seed = 10
+
+	err := model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("LinearSVM Fit failed: %v\n", err)
+	}
+
+	predictions, _ := model.Predict(features)
+	matrix, _ := GenerateConfusionMatrix(targets, predictions)
+
+	accuracy := float64(matrix.TruePositives + matrix.TrueNegatives) / 200.0
+	if accuracy < 0.8 {
+		t.Errorf("Expected at least 80%% accuracy on overlapping classes, got %v", accuracy)
+	}
+}
+
+func TestLinearSVMErrors(t *testing.T) {
+	_, err := InitLinearSVM[float64, uint64](0, 10)
+	if err == nil {
+		t.Errorf("Expected an error for a zero lambda")
+	}
+
+	model, _ := InitLinearSVM[float64, uint64](0.1, 10)
+	features, _ := InitTensor64(2, 2)
+
+	_, err = model.Predict(features)
+	if err == nil {
+		t.Errorf("Expected an error when predicting before Fit")
+	}
+
+	targets, _ := InitTensor64(2, 1)
+	targets.Data[0] = -1.0
+
+	err = model.Fit(features, targets)
+	if err == nil {
+		t.Errorf("Expected an error for targets other than 0 and 1")
+	}
+}
+
+// squaredHingeOptimum minimizes (lambda / 2) * ||w||^2 plus the mean squared
+// hinge loss by full-batch gradient descent, without any projection.
+func squaredHingeOptimum(rows [][]float64, signs []float64, lambda float64) ([]float64, float64) {
+	numFeatures := len(rows[0])
+	weights := make([]float64, numFeatures)
+	bias := 0.0
+
+	// the gradient is Lipschitz with constant lambda + 2 * max ||x||^2
+	smoothness := lambda
+	for _, row := range rows {
+		smoothness = math.Max(smoothness, lambda + 2.0 * (dotFloat64(row, row) + 1.0))
+	}
+	rate := 1.0 / smoothness
+
+	for iteration := 0; iteration < 20000; iteration++ {
+		gradient := make([]float64, numFeatures)
+		for m := range gradient {
+			gradient[m] = lambda * weights[m]
+		}
+		gradientBias := 0.0
+
+		for n, row := range rows {
+			slack := 1.0 - signs[n] * (dotFloat64(weights, row) + bias)
+			if slack <= 0 {
+				continue
+			}
+
+			scale := -2.0 * slack * signs[n] / float64(len(rows))
+			for m := range gradient {
+				gradient[m] += scale * row[m]
+			}
+			gradientBias += scale
+		}
+
+		for m := range weights {
+			weights[m] -= rate * gradient[m]
+		}
+		bias -= rate * gradientBias
+	}
+
+	return weights, bias
+}
+
+func TestLinearSVMSquaredHingeOptimum(t *testing.T) {
+	features, targets := svmData(3.0)
+	lambda := 0.5
+
+	model, _ := InitLinearSVM[float64, uint64](lambda, 300)
+	model.SquaredHinge = true
+	model.Seed = 3
+
+	err := model.Fit(features, targets)
+	if err != nil {
+		t.Fatalf("LinearSVM Fit failed: %v\n", err)
+	}
+
+	scaled, _ := model.Scaler.Transform(features)
+	rows, _ := matrixToFloat64(scaled)
+	signs := make([]float64, len(rows))
+	for n, val := range targets.Data {
+		signs[n] = 2.0 * val - 1.0
+	}
+
+	weights, bias := squaredHingeOptimum(rows, signs, lambda)
+
+	for m, expected := range weights {
+		if math.Abs(model.Weights.Data[m] - expected) > 0.02 {
+			t.Errorf("Weight %v: got %v, expected the unprojected optimum %v", m, model.Weights.Data[m], expected)
+		}
+	}
+
+	if math.Abs(float64(model.Bias) - bias) > 0.05 {
+		t.Errorf("Bias: got %v, expected the unprojected optimum %v", model.Bias, bias)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"math"
+	"slices"
 	"time"
 )
 
@@ -81,6 +82,10 @@ func InitRandomTensor64(maxVal float64, shape ...uint64) (*Tensor[float64, uint6
 	return result, err
 }
 
+// InitTargetTensor computes weights[0] + sum(weights[m + 1] * x[m]) plus
+// uniform noise in [-1, 1) for every row of xBase, so weights holds the bias
+// followed by one weight per feature. MakeRegression generates both the
+// features and the targets with configurable noise.
 func InitTargetTensor[T Numeric, S Index](
 	xBase *Tensor[T, S],
 	weights []T) (*Tensor[T, S], error) {
@@ -89,6 +94,11 @@ func InitTargetTensor[T Numeric, S Index](
 	}
 
 	numSamples := xBase.Shape[0]
+	numFeatures := xBase.Shape[1]
+
+	if S(len(weights)) != numFeatures + 1 {
+		return &Tensor[T, S]{}, fmt.Errorf("Expecting %v weights (bias first), got %v", numFeatures + 1, len(weights))
+	}
 
 	y, err := InitTensor[T, S]([]S{numSamples, 1})
 	if err != nil {
@@ -96,22 +106,21 @@ func InitTargetTensor[T Numeric, S Index](
 	}
 
 	bias := T(weights[0])
-	w1 := T(weights[1])
-	w2 := T(weights[2])
 
 	rowStride := xBase.Strides[0]
+	colStride := xBase.Strides[1]
 
 	for n := S(0); n < numSamples; n++ {
 		startIdx := n * rowStride
 
-		x1 := xBase.Data[startIdx]
-		x2 := xBase.Data[startIdx + 1]
+		yN := bias
+		for m := S(0); m < numFeatures; m++ {
+			yN += weights[m + 1] * xBase.Data[startIdx + m * colStride]
+		}
 
 		noise := T(rand.Float64() * 2 - 1.0)
 
-		yN := bias + (w1 * x1) + (w2 * x2) + noise
-
-		y.Data[n] = yN
+		y.Data[n] = yN + noise
 	}
 
 	return y, nil
@@ -187,24 +196,26 @@ func (t *Tensor[T, S]) Transpose(axes ...S) (*Tensor[T, S], error) {
 
 }
 
-func (t *Tensor[T, S]) Dot(other *Tensor[T, S]) (*Tensor[T, S], error) {
+// dotDims validates the operands of Dot and returns the rows and columns of
+// the product.
+func (t *Tensor[T, S]) dotDims(other *Tensor[T, S]) (S, S, error) {
 	lenA := len(t.Shape)
 	lenB := len(other.Shape)
 
 	if lenA < 2 || lenB < 2 {
-		return &Tensor[T, S]{}, errors.New("Tensors must at least 2 dimensions for Dot product calculation")
+		return 0, 0, errors.New("Tensors must at least 2 dimensions for Dot product calculation")
 	}
 
 	batchSizeA := S(lenA - 2)
 	batchSizeB := S(lenB - 2)
 
 	if batchSizeA != batchSizeB {
-		return &Tensor[T, S]{}, errors.New("Batch dimensions do not match")
+		return 0, 0, errors.New("Batch dimensions do not match")
 	}
 
 	for n := S(0); n < batchSizeA; n++ {
 		if t.Shape[n] != other.Shape[n] {
-			return &Tensor[T, S]{}, errors.New("Shape in batch dimensions do not match") 
+			return 0, 0, errors.New("Shape in batch dimensions do not match") 
 		}
 	}
 
@@ -215,21 +226,79 @@ func (t *Tensor[T, S]) Dot(other *Tensor[T, S]) (*Tensor[T, S], error) {
 
 
 	if K1 != K2 {
-		return &Tensor[T, S]{}, errors.New(fmt.Sprintf("Inner dimensions do not match. %v != %v", K1, K2))
+		return 0, 0, errors.New(fmt.Sprintf("Inner dimensions do not match. %v != %v", K1, K2))
 	}
 
-	K := K1
+	return M, N, nil
+}
+
+func (t *Tensor[T, S]) Dot(other *Tensor[T, S]) (*Tensor[T, S], error) {
+	M, N, err := t.dotDims(other)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
 
-	newShape := make([]S, lenA)
-	copy(newShape, t.Shape[:batchSizeA])
-	newShape[batchSizeA] = M
-	newShape[batchSizeA + 1] = N
+	batchSize := len(t.Shape) - 2
+	newShape := make([]S, len(t.Shape))
+	copy(newShape, t.Shape[:batchSize])
+	newShape[batchSize] = M
+	newShape[batchSize + 1] = N
 
 	result, err := InitTensor[T, S](newShape)
 	if err != nil {
 		return &Tensor[T, S]{}, err
 	}
 
+	t.dotInto(result, other)
+
+	return result, nil
+}
+
+// DotInto writes the product of a and b into dst, which must be contiguous
+// and already have the product's shape. It lets training loops reuse one
+// buffer across iterations. dst is overwritten while a and b are still being
+// read, so it must not share storage with either of them.
+func DotInto[T Numeric, S Index](dst, a, b *Tensor[T, S]) error {
+	M, N, err := a.dotDims(b)
+	if err != nil {
+		return err
+	}
+
+	if sharesStorage(dst.Data, a.Data) || sharesStorage(dst.Data, b.Data) {
+		return errors.New("DotInto destination must not share storage with its operands")
+	}
+
+	batchSize := len(a.Shape) - 2
+	if !dst.IsContiguous() || len(dst.Shape) != len(a.Shape) ||
+		!slices.Equal(dst.Shape[:batchSize], a.Shape[:batchSize]) ||
+		dst.Shape[batchSize] != M || dst.Shape[batchSize + 1] != N {
+		return fmt.Errorf("Destination must be a contiguous tensor with %v rows and %v columns, got shape %v", M, N, dst.Shape)
+	}
+
+	a.dotInto(dst, b)
+	return nil
+}
+
+// sharesStorage reports whether a and b are views of the same backing array.
+// Reslicing keeps the end of the capacity, so such views share their last
+// element.
+func sharesStorage[T any](a, b []T) bool {
+	if cap(a) == 0 || cap(b) == 0 {
+		return false
+	}
+
+	return &a[:cap(a)][cap(a) - 1] == &b[:cap(b)][cap(b) - 1]
+}
+
+// dotInto overwrites result, whose shape has been checked, with t . other.
+func (t *Tensor[T, S]) dotInto(result *Tensor[T, S], other *Tensor[T, S]) {
+	lenA := len(t.Shape)
+	batchSizeA := S(lenA - 2)
+
+	M := t.Shape[batchSizeA]
+	K := t.Shape[batchSizeA + 1]
+	N := other.Shape[batchSizeA + 1]
+
 	totalMul := S(1)
 
 	for _, dim := range t.Shape[:batchSizeA] {
@@ -252,33 +321,57 @@ func (t *Tensor[T, S]) Dot(other *Tensor[T, S]) (*Tensor[T, S], error) {
 	matrixSizeB := K * N
 	matrixSizeRes := M * N
 
+	// the contiguous path below accumulates, so start from zero
+	clear(result.Data[:batchCount * matrixSizeRes])
+
 	for batchIdx := S(0); batchIdx < batchCount; batchIdx++ {
 		batchOffsetA := batchIdx * matrixSizeA
 		batchOffsetB := batchIdx * matrixSizeB
 
 		batchOffsetResult := batchIdx * matrixSizeRes
 
+		// when rows of B are contiguous, accumulate whole rows of the result
+		// at a time so the innermost loop walks memory sequentially
+		if strideBCol == 1 && strideResCol == 1 {
+			for i := S(0); i < R; i++ {
+				rowOffA := batchOffsetA + i * strideARow
+				rowOffRes := batchOffsetResult + i * strideResRow
+				resultRow := result.Data[rowOffRes: rowOffRes + C]
+
+				for k := S(0); k < K; k++ {
+					valA := t.Data[rowOffA + k * strideACol]
+
+					rowOffB := batchOffsetB + k * strideBRow
+					rowB := other.Data[rowOffB: rowOffB + C]
+
+					for j := range resultRow {
+						resultRow[j] += valA * rowB[j]
+					}
+				}
+			}
+			continue
+		}
+
 		for i := S(0); i < R; i++ {
 			rowOffA := batchOffsetA + i * strideARow
 			rowOffRes := batchOffsetResult + i * strideResRow
 			for j := S(0); j < C; j++ {
 				sum := *new(T)
 
-				colOffB := batchOffsetB + j * strideBCol
+				offA := rowOffA
+				offB := batchOffsetB + j * strideBCol
 
 				for k := S(0); k < K; k++ {
-					valA := t.Data[rowOffA + k * strideACol]
+					sum += t.Data[offA] * other.Data[offB]
 
-					valB := other.Data[colOffB + k * strideBRow]
-
-					sum += valA * valB
+					offA += strideACol
+					offB += strideBRow
 				}
 				result.Data[rowOffRes + j * strideResCol] = sum
 			}
 		}
 	}
 
-	return result, nil
 }
 
 func (t *Tensor[T, S]) Add(other *Tensor[T, S]) (*Tensor[T, S], error) {
@@ -539,6 +632,21 @@ func R2Score[T Numeric, S Index](
 	return T(r2), nil
 }
 
+func stableSigmoid(z float64) float64 {
+	// only ever exponentiate a non-positive value so math.Exp cannot overflow
+	if z >= 0 {
+		return 1.0 / (1.0 + math.Exp(-z))
+	}
+
+	expZ := math.Exp(z)
+	return expZ / (1.0 + expZ)
+}
+
+func stableSoftplus(z float64) float64 {
+	// log(1 + e^z) = max(z, 0) + log(1 + e^-|z|)
+	return math.Max(z, 0) + math.Log1p(math.Exp(-math.Abs(z)))
+}
+
 func Sigmoid[T Numeric, S Index](Z *Tensor[T, S]) (*Tensor[T, S], error) {
 	result, err := InitTensor[T, S](Z.Shape)
 	if err != nil {
@@ -546,41 +654,55 @@ func Sigmoid[T Numeric, S Index](Z *Tensor[T, S]) (*Tensor[T, S], error) {
 	}
 
 	for n := 0; n < len(Z.Data); n++ {
-		floatZ := float64(Z.Data[n])
-		sigVal := 1.0 / (1.0 + math.Exp(-floatZ))
-		result.Data[n] = T(sigVal)
+		result.Data[n] = T(stableSigmoid(float64(Z.Data[n])))
 	}
 
 	return result, nil
 }
 
-func Classify[T Numeric, S Index](predicted *Tensor[T, S], threshold T) (*Tensor[T, S], error) {
-	labels, err := InitTensor[T, S](predicted.Shape)
+func LogSigmoid[T Numeric, S Index](Z *Tensor[T, S]) (*Tensor[T, S], error) {
+	result, err := InitTensor[T, S](Z.Shape)
 	if err != nil {
 		return &Tensor[T, S]{}, err
 	}
 
-	for n := 0; n < len(predicted.Data); n++ {
-		if predicted.Data[n] >= threshold {
-			labels.Data[n] = T(1.0)
-		} else {
-			labels.Data[n] = T(0.0)
-		}
+	for n := 0; n < len(Z.Data); n++ {
+		result.Data[n] = T(-stableSoftplus(-float64(Z.Data[n])))
 	}
 
-	return labels, nil
+	return result, nil
+}
+
+func Softplus[T Numeric, S Index](Z *Tensor[T, S]) (*Tensor[T, S], error) {
+	result, err := InitTensor[T, S](Z.Shape)
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n := 0; n < len(Z.Data); n++ {
+		result.Data[n] = T(stableSoftplus(float64(Z.Data[n])))
+	}
+
+	return result, nil
 }
 
+// Classify thresholds predicted into 0/1 labels, 1 where a value is at
+// least threshold.
+func Classify[T Numeric, S Index](predicted *Tensor[T, S], threshold T) (*Tensor[T, S], error) {
+	return CompareScalar(predicted, GreaterEqualComparison, threshold)
+}
+
+const logEpsilon = 1e-12
+
 func Log[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
 	result, err := InitTensor[T, S](input.Shape)
 	if err != nil {
 		return &Tensor[T, S]{}, err
 	}
 
-	epsilon := 1e-12 // prevents Log(0) or negative infinity
-
 	for n := 0; n < len(input.Data); n++ {
-		val := math.Log(float64(input.Data[n]) + epsilon)
+		// adding epsilon prevents Log(0) or negative infinity
+		val := math.Log(float64(input.Data[n]) + logEpsilon)
 		result.Data[n] = T(val)
 	}
 
@@ -588,49 +710,123 @@ func Log[T Numeric, S Index](input *Tensor[T, S]) (*Tensor[T, S], error) {
 }
 
 func CalculateCost[T Numeric, S Index](predicted, expected *Tensor[T, S]) (T, error) {
-	expectFirstShape := S(expected.Shape[0])
+	if len(predicted.Data) != len(expected.Data) {
+		return T(0.0), errors.New("Predicted and expected tensors must have the same length")
+	}
 
-	logPredicted, err := Log(predicted)
-	if err != nil {
-		return T(0.0), err
+	numSamples := len(expected.Data)
+	if numSamples == 0 {
+		return T(0.0), nil
 	}
 
-	costTerm, err := expected.Hadamard(logPredicted)
-	if err != nil {
-		return T(0.0), err
+	totalLoss := 0.0
+	for n := 0; n < numSamples; n++ {
+		p := math.Min(math.Max(float64(predicted.Data[n]), logEpsilon), 1.0 - logEpsilon)
+		y := float64(expected.Data[n])
+
+		totalLoss -= y * math.Log(p) + (1.0 - y) * math.Log(1.0 - p)
 	}
 
-	oneMinusPredicted, err := predicted.SubtractScalar(T(1.0))
-	if err != nil {
-		return T(0.0), err
+	return T(totalLoss / float64(numSamples)), nil
+}
+
+// BinaryCrossEntropyWithLogits computes the same cost as CalculateCost from
+// the linear output before Sigmoid, which stays finite however large the
+// logits become.
+func BinaryCrossEntropyWithLogits[T Numeric, S Index](logits, expected *Tensor[T, S]) (T, error) {
+	if len(logits.Data) != len(expected.Data) {
+		return T(0.0), errors.New("Logit and expected tensors must have the same length")
 	}
 
-	logOneMinusPredicted, err := Log(oneMinusPredicted)
-	if err != nil {
-		return T(0.0), err
+	numSamples := len(expected.Data)
+	if numSamples == 0 {
+		return T(0.0), nil
 	}
 
-	oneMinusExpected, err := expected.SubtractScalar(T(1.0))
-	if err != nil {
-		return T(0.0), err
+	totalLoss := 0.0
+	for n := 0; n < numSamples; n++ {
+		z := float64(logits.Data[n])
+		y := float64(expected.Data[n])
+
+		// -(y * log(sigmoid(z)) + (1 - y) * log(1 - sigmoid(z))) = softplus(z) - y * z
+		totalLoss += stableSoftplus(z) - y * z
 	}
 
-	oneMinusesHadamard, err := oneMinusExpected.Hadamard(logOneMinusPredicted)
-	if err != nil {
-		return T(0.0), err
+	return T(totalLoss / float64(numSamples)), nil
+}
+
+func Softmax[T Numeric, S Index](Z *Tensor[T, S]) (*Tensor[T, S], error) {
+	if len(Z.Shape) == 0 {
+		return &Tensor[T, S]{}, errors.New("Softmax requires at least one dimension")
 	}
 
-	totalSampleLoss, err := costTerm.Add(oneMinusesHadamard)
+	input, err := Z.Contiguous()
 	if err != nil {
-		return T(0.0), err
+		return &Tensor[T, S]{}, err
 	}
-	sumOfAllLosses, err := totalSampleLoss.Sum()
+
+	result, err := InitTensor[T, S](input.Shape)
 	if err != nil {
-		return T(0.0), err
+		return &Tensor[T, S]{}, err
 	}
-	finalCost := -(T(1.0) / T(expectFirstShape)) * sumOfAllLosses
 
-	return finalCost, nil
+	numClasses := int(input.Shape[len(input.Shape) - 1])
+	if numClasses == 0 {
+		return result, nil
+	}
+
+	for rowStart := 0; rowStart < len(input.Data); rowStart += numClasses {
+		row := input.Data[rowStart: rowStart + numClasses]
+
+		// subtracting the row maximum keeps math.Exp from overflowing
+		maxVal := float64(row[0])
+		for _, val := range row {
+			maxVal = math.Max(maxVal, float64(val))
+		}
+
+		sum := 0.0
+		for _, val := range row {
+			sum += math.Exp(float64(val) - maxVal)
+		}
+
+		for n, val := range row {
+			result.Data[rowStart + n] = T(math.Exp(float64(val) - maxVal) / sum)
+		}
+	}
+
+	return result, nil
+}
+
+func CategoricalCrossEntropy[T Numeric, S Index](predicted, expected *Tensor[T, S]) (T, error) {
+	if len(predicted.Shape) != 2 || len(expected.Shape) != 2 {
+		return T(0.0), errors.New("CategoricalCrossEntropy requires 2D tensors")
+	}
+
+	if predicted.Shape[0] != expected.Shape[0] || predicted.Shape[1] != expected.Shape[1] {
+		return T(0.0), fmt.Errorf("Shapes do not match: %v != %v", predicted.Shape, expected.Shape)
+	}
+
+	numSamples := predicted.Shape[0]
+	if numSamples == 0 {
+		return T(0.0), nil
+	}
+
+	numClasses := predicted.Shape[1]
+
+	total := 0.0
+	for n := S(0); n < numSamples; n++ {
+		for c := S(0); c < numClasses; c++ {
+			y := float64(expected.Data[n * expected.Strides[0] + c * expected.Strides[1]])
+			if y == 0 {
+				continue
+			}
+
+			p := float64(predicted.Data[n * predicted.Strides[0] + c * predicted.Strides[1]])
+			total -= y * math.Log(math.Max(p, logEpsilon))
+		}
+	}
+
+	return T(total / float64(numSamples)), nil
 }
 
 func (t *Tensor[T, S]) GetSlice(axis S, index S) (*Tensor[T, S], error) {
@@ -929,3 +1125,135 @@ func (t *Tensor[T, S]) IsContiguous() bool {
 	}
 	return true
 }
+
+// elements returns the values of t in row-major order. Views from GetSlice
+// share the tail of their parent's Data, so only the leading elements belong
+// to the view.
+func (t *Tensor[T, S]) elements() ([]T, error) {
+	contiguous, err := t.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+
+	numElements := S(1)
+	for _, dim := range t.Shape {
+		numElements *= dim
+	}
+
+	return contiguous.Data[:numElements], nil
+}
+
+// selectRows copies the given rows of a 2D tensor into a new contiguous tensor.
+func selectRows[T Numeric, S Index](input *Tensor[T, S], rows []int) (*Tensor[T, S], error) {
+	if len(input.Shape) != 2 {
+		return &Tensor[T, S]{}, errors.New("Row selection requires a 2D tensor")
+	}
+
+	source, err := input.Contiguous()
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	numCols := input.Shape[1]
+	result, err := InitTensor[T, S]([]S{S(len(rows)), numCols})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n, row := range rows {
+		if row < 0 || S(row) >= input.Shape[0] {
+			return &Tensor[T, S]{}, fmt.Errorf("Row %v is out of range for shape %v", row, input.Shape)
+		}
+
+		copy(result.Data[S(n) * numCols: S(n + 1) * numCols], source.Data[S(row) * numCols: S(row + 1) * numCols])
+	}
+
+	return result, nil
+}
+
+// nanTotals returns the sum and count of the elements that are not NaN.
+func nanTotals[T Numeric](data []T) (float64, int) {
+	sum := 0.0
+	count := 0
+	for _, val := range data {
+		if math.IsNaN(float64(val)) {
+			continue
+		}
+		sum += float64(val)
+		count++
+	}
+
+	return sum, count
+}
+
+// NanSum is Sum over every element that is not NaN.
+func (t *Tensor[T, S]) NanSum() (T, error) {
+	data, err := t.elements()
+	if err != nil {
+		return T(0), err
+	}
+
+	sum, _ := nanTotals(data)
+	return T(sum), nil
+}
+
+// NanMean is Mean over every element that is not NaN. It is NaN when every
+// element is NaN.
+func (t *Tensor[T, S]) NanMean() (T, error) {
+	data, err := t.elements()
+	if err != nil {
+		return T(0), err
+	}
+
+	if len(data) == 0 {
+		return T(0), nil
+	}
+
+	sum, count := nanTotals(data)
+	if count == 0 {
+		return T(math.NaN()), nil
+	}
+
+	return T(sum / float64(count)), nil
+}
+
+// nanReduceAxis applies reduce to every column (axis 0) or row (axis 1) of a
+// 2D tensor.
+func nanReduceAxis[T Numeric, S Index](t *Tensor[T, S], axis S, reduce func(*Tensor[T, S]) (T, error)) (*Tensor[T, S], error) {
+	if len(t.Shape) != 2 || axis > 1 {
+		return &Tensor[T, S]{}, errors.New("NaN reductions along an axis require a 2D tensor and an axis of 0 or 1")
+	}
+
+	// reducing along the rows leaves one value per column, and vice versa
+	sliceAxis := 1 - axis
+
+	result, err := InitTensor[T, S]([]S{t.Shape[sliceAxis]})
+	if err != nil {
+		return &Tensor[T, S]{}, err
+	}
+
+	for n := S(0); n < t.Shape[sliceAxis]; n++ {
+		slice, err := t.GetSlice(sliceAxis, n)
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+
+		result.Data[n], err = reduce(slice)
+		if err != nil {
+			return &Tensor[T, S]{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// NanSumAxis sums a 2D tensor along axis, skipping NaNs. Axis 0 gives one
+// total per column, axis 1 one per row.
+func (t *Tensor[T, S]) NanSumAxis(axis S) (*Tensor[T, S], error) {
+	return nanReduceAxis(t, axis, (*Tensor[T, S]).NanSum)
+}
+
+// NanMeanAxis averages a 2D tensor along axis, skipping NaNs.
+func (t *Tensor[T, S]) NanMeanAxis(axis S) (*Tensor[T, S], error) {
+	return nanReduceAxis(t, axis, (*Tensor[T, S]).NanMean)
+}
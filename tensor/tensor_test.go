@@ -476,6 +476,25 @@ func TestTargetTensor(t *testing.T) {
 	}
 }
 
+func TestTargetTensorAnyFeatureCount(t *testing.T) {
+	t1, _ := InitTensor[float64, uint]([]uint{2, 3})
+	t1.Data = []float64{1.0, 2.0, 3.0, 0.0, 0.0, 1.0}
+
+	t2, err := InitTargetTensor[float64, uint](t1, []float64{1.0, 2.0, 3.0, 4.0})
+	if err != nil {
+		t.Fatalf("Error creating target tensor: %v\n", err)
+	}
+
+	if t2.Data[0] < 20.0 || t2.Data[0] >= 22.0 || t2.Data[1] < 4.0 || t2.Data[1] >= 6.0 {
+		t.Errorf("Unexpected values in target tensor: %v\n", t2.Data)
+	}
+
+	_, err = InitTargetTensor[float64, uint](t1, []float64{1.0, 2.0, 3.0})
+	if err == nil {
+		t.Errorf("Expected an error when the weights do not match the features")
+	}
+}
+
 func TestNorm(t *testing.T) {
 	t1, _ := InitTensor[float64, uint]([]uint{0, 0})
 	norm, err := t1.Norm()
@@ -909,3 +928,197 @@ func TestContiguous(t *testing.T) {
 		}
 	}
 }
+
+func TestSoftmax(t *testing.T) {
+	tol := 1e-9
+
+	t1, _ := InitTensor64(2, 3)
+	t1.Data = []float64{1.0, 2.0, 3.0, 1000.0, 1000.0, 1000.0}
+
+	result, err := Softmax(t1)
+	if err != nil {
+		t.Errorf("Softmax failed: %v\n", err)
+	}
+
+	denominator := math.Exp(1.0) + math.Exp(2.0) + math.Exp(3.0)
+	expected := []float64{
+		math.Exp(1.0) / denominator, math.Exp(2.0) / denominator, math.Exp(3.0) / denominator,
+		1.0 / 3.0, 1.0 / 3.0, 1.0 / 3.0,
+	}
+
+	for n := range expected {
+		if math.Abs(result.Data[n] - expected[n]) > tol {
+			t.Errorf("Unexpected Softmax values: got %v, expected %v", result.Data, expected)
+			break
+		}
+	}
+}
+
+func TestCategoricalCrossEntropy(t *testing.T) {
+	tol := 1e-9
+
+	predicted, _ := InitTensor64(2, 3)
+	predicted.Data = []float64{0.7, 0.2, 0.1, 0.0, 0.5, 0.5}
+
+	expected, _ := InitTensor64(2, 3)
+	expected.Data = []float64{1.0, 0.0, 0.0, 0.0, 0.0, 1.0}
+
+	cost, err := CategoricalCrossEntropy(predicted, expected)
+	if err != nil {
+		t.Errorf("CategoricalCrossEntropy failed: %v\n", err)
+	}
+
+	expectedCost := -(math.Log(0.7) + math.Log(0.5)) / 2.0
+	if math.Abs(cost - expectedCost) > tol {
+		t.Errorf("Unexpected cross entropy: got %v, expected %v", cost, expectedCost)
+	}
+
+	expected.Data = []float64{0.0, 0.0, 1.0, 1.0, 0.0, 0.0}
+	cost, _ = CategoricalCrossEntropy(predicted, expected)
+	if math.IsInf(cost, 0) || math.IsNaN(cost) {
+		t.Errorf("Cross entropy of a zero probability should be finite, got %v", cost)
+	}
+}
+
+func TestSigmoidExtremeValues(t *testing.T) {
+	t1, _ := InitTensor64(4)
+	t1.Data = []float64{1000.0, -1000.0, math.MaxFloat64, -math.MaxFloat64}
+
+	result, err := Sigmoid(t1)
+	if err != nil {
+		t.Errorf("Sigmoid failed on extreme values: %v\n", err)
+	}
+
+	expected := []float64{1.0, 0.0, 1.0, 0.0}
+	for n := range expected {
+		if result.Data[n] != expected[n] {
+			t.Errorf("Unexpected Sigmoid of extreme values: got %v, expected %v", result.Data, expected)
+			break
+		}
+	}
+}
+
+func TestSoftplusAndLogSigmoid(t *testing.T) {
+	tol := 1e-9
+
+	t1, _ := InitTensor64(5)
+	t1.Data = []float64{0.0, 2.0, -2.0, 1000.0, -1000.0}
+
+	softplus, err := Softplus(t1)
+	if err != nil {
+		t.Errorf("Softplus failed: %v\n", err)
+	}
+
+	expectedSoftplus := []float64{math.Log(2.0), math.Log(1.0 + math.Exp(2.0)), math.Log(1.0 + math.Exp(-2.0)), 1000.0, 0.0}
+	for n := range expectedSoftplus {
+		if math.Abs(softplus.Data[n] - expectedSoftplus[n]) > tol {
+			t.Errorf("Unexpected Softplus values: got %v, expected %v", softplus.Data, expectedSoftplus)
+			break
+		}
+	}
+
+	logSigmoid, err := LogSigmoid(t1)
+	if err != nil {
+		t.Errorf("LogSigmoid failed: %v\n", err)
+	}
+
+	expectedLogSigmoid := []float64{math.Log(0.5), math.Log(0.880797077977882), math.Log(0.119202922022118), 0.0, -1000.0}
+	for n := range expectedLogSigmoid {
+		if math.Abs(logSigmoid.Data[n] - expectedLogSigmoid[n]) > tol {
+			t.Errorf("Unexpected LogSigmoid values: got %v, expected %v", logSigmoid.Data, expectedLogSigmoid)
+			break
+		}
+	}
+}
+
+func TestCalculateCostExtremePredictions(t *testing.T) {
+	expected, _ := InitTensor64(4, 1)
+	expected.Data = []float64{1.0, 0.0, 1.0, 0.0}
+
+	predicted, _ := InitTensor64(4, 1)
+	predicted.Data = []float64{1.0, 0.0, 0.0, 1.0}
+
+	cost, err := CalculateCost(predicted, expected)
+	if err != nil {
+		t.Errorf("CalculateCost failed on extreme predictions: %v\n", err)
+	}
+
+	if math.IsNaN(cost) || math.IsInf(cost, 0) {
+		t.Errorf("CalculateCost should clip probabilities of 0 and 1, got %v", cost)
+	}
+
+	expectedCost := -math.Log(1e-12) / 2.0
+	if math.Abs(cost - expectedCost) > 1e-3 {
+		t.Errorf("Unexpected clipped cost: got %v, expected about %v", cost, expectedCost)
+	}
+}
+
+func TestBinaryCrossEntropyWithLogits(t *testing.T) {
+	tol := 1e-9
+
+	expected, _ := InitTensor64(3, 1)
+	expected.Data = []float64{1.0, 0.0, 1.0}
+
+	logits, _ := InitTensor64(3, 1)
+	logits.Data = []float64{2.0, -0.5, 0.3}
+
+	probabilities, _ := Sigmoid(logits)
+	fromProbabilities, _ := CalculateCost(probabilities, expected)
+
+	fromLogits, err := BinaryCrossEntropyWithLogits(logits, expected)
+	if err != nil {
+		t.Errorf("BinaryCrossEntropyWithLogits failed: %v\n", err)
+	}
+
+	if math.Abs(fromLogits - fromProbabilities) > tol {
+		t.Errorf("Cross entropy from logits %v does not match cost from probabilities %v", fromLogits, fromProbabilities)
+	}
+
+	logits.Data = []float64{1000.0, -1000.0, -1000.0}
+
+	extreme, err := BinaryCrossEntropyWithLogits(logits, expected)
+	if err != nil {
+		t.Errorf("BinaryCrossEntropyWithLogits failed on extreme logits: %v\n", err)
+	}
+
+	if math.Abs(extreme - 1000.0 / 3.0) > tol {
+		t.Errorf("Unexpected cross entropy for extreme logits: got %v, expected %v", extreme, 1000.0 / 3.0)
+	}
+}
+
+func TestNanReductions(t *testing.T) {
+	nan := math.NaN()
+
+	input, _ := InitTensor64(3, 2)
+	input.Data = []float64{1.0, nan, 3.0, 4.0, nan, nan}
+
+	sum, _ := input.NanSum()
+	mean, _ := input.NanMean()
+	if sum != 8.0 || math.Abs(mean - 8.0 / 3.0) > 1e-12 {
+		t.Errorf("Unexpected NanSum %v or NanMean %v", sum, mean)
+	}
+
+	columnSums, err := input.NanSumAxis(0)
+	if err != nil {
+		t.Fatalf("NanSumAxis failed: %v", err)
+	}
+	if columnSums.Shape[0] != 2 || columnSums.Data[0] != 4.0 || columnSums.Data[1] != 4.0 {
+		t.Errorf("Unexpected column sums: %v", columnSums.Data)
+	}
+
+	columnMeans, _ := input.NanMeanAxis(0)
+	if columnMeans.Data[0] != 2.0 || columnMeans.Data[1] != 4.0 {
+		t.Errorf("Unexpected column means: %v", columnMeans.Data)
+	}
+
+	// a row made only of NaNs averages to NaN
+	rowMeans, _ := input.NanMeanAxis(1)
+	if rowMeans.Shape[0] != 3 || rowMeans.Data[0] != 1.0 || rowMeans.Data[1] != 3.5 || !math.IsNaN(rowMeans.Data[2]) {
+		t.Errorf("Unexpected row means: %v", rowMeans.Data)
+	}
+
+	_, err = input.NanSumAxis(2)
+	if err == nil {
+		t.Errorf("Expected an error for an axis out of range")
+	}
+}